@@ -0,0 +1,285 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var actionItemTransitions = map[string][]string{
+	"open":        {"in-progress", "done", "dropped"},
+	"in-progress": {"open", "done", "dropped"},
+	"done":        {"open"},
+	"dropped":     {"open"},
+}
+
+func canTransitionActionItem(from, to string) bool {
+	for _, next := range actionItemTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func applyActionItemStatus(item *ActionItem, status string) {
+	item.Status = status
+	if status == "done" {
+		now := time.Now()
+		item.CompletedAt = &now
+	} else {
+		item.CompletedAt = nil
+	}
+}
+
+func validateActionItem(item *ActionItem) (int, string) {
+	if item.Title == "" {
+		return http.StatusBadRequest, "Title is required"
+	}
+	if item.FeedbackID == nil && item.MeetingTitle == "" {
+		return http.StatusBadRequest, "Action item must be linked to a feedback or a meeting"
+	}
+	if item.FeedbackID != nil {
+		var count int64
+		DB.Model(&Feedback{}).Where("id = ?", *item.FeedbackID).Count(&count)
+		if count == 0 {
+			return http.StatusNotFound, "Feedback not found"
+		}
+	}
+	var count int64
+	DB.Model(&TeamMember{}).Where("id = ?", item.AssigneeID).Count(&count)
+	if count == 0 {
+		return http.StatusNotFound, "Assignee not found"
+	}
+	return 0, ""
+}
+
+func openActionItems(query *gorm.DB) *gorm.DB {
+	return query.Where("status IN ?", []string{"open", "in-progress"})
+}
+
+func overdueActionItems(query *gorm.DB, now time.Time) *gorm.DB {
+	return openActionItems(query).Where("due_date IS NOT NULL AND due_date < ?", now)
+}
+
+func CreateActionItem(c *gin.Context) {
+	var item ActionItem
+	if err := c.ShouldBindJSON(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item.Status = "open"
+	item.CompletedAt = nil
+	item.RetrospectiveID = nil
+	if code, msg := validateActionItem(&item); msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	if err := DB.Create(&item).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+func GetActionItems(c *gin.Context) {
+	query := DB.Model(&ActionItem{})
+
+	if assigneeID := c.Query("assignee_id"); assigneeID != "" {
+		if id, err := strconv.Atoi(assigneeID); err == nil {
+			query = query.Where("assignee_id = ?", id)
+		}
+	}
+
+	if feedbackID := c.Query("feedback_id"); feedbackID != "" {
+		if id, err := strconv.Atoi(feedbackID); err == nil {
+			query = query.Where("feedback_id = ?", id)
+		}
+	}
+
+	if retrospectiveID := c.Query("retrospective_id"); retrospectiveID != "" {
+		if id, err := strconv.Atoi(retrospectiveID); err == nil {
+			query = query.Where("retrospective_id = ?", id)
+		}
+	}
+
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var items []ActionItem
+	if err := query.Order("due_date IS NULL, due_date, id").Find(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+func GetOverdueActionItems(c *gin.Context) {
+	query := overdueActionItems(DB.Model(&ActionItem{}), time.Now())
+
+	if assigneeID := c.Query("assignee_id"); assigneeID != "" {
+		if id, err := strconv.Atoi(assigneeID); err == nil {
+			query = query.Where("assignee_id = ?", id)
+		}
+	}
+
+	var items []ActionItem
+	if err := query.Order("due_date, id").Find(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+func GetActionItem(c *gin.Context) {
+	id := c.Param("id")
+	var item ActionItem
+	if err := DB.First(&item, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Action item not found"})
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+func UpdateActionItem(c *gin.Context) {
+	id := c.Param("id")
+	var item ActionItem
+	if err := DB.First(&item, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Action item not found"})
+		return
+	}
+
+	var input ActionItem
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if input.Status != "" && input.Status != item.Status {
+		if _, ok := actionItemTransitions[input.Status]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Status must be one of 'open', 'in-progress', 'done' or 'dropped'"})
+			return
+		}
+		if !canTransitionActionItem(item.Status, input.Status) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Cannot change status from '" + item.Status + "' to '" + input.Status + "'"})
+			return
+		}
+		applyActionItemStatus(&item, input.Status)
+	}
+
+	item.Title = input.Title
+	item.Description = input.Description
+	item.FeedbackID = input.FeedbackID
+	item.MeetingTitle = input.MeetingTitle
+	item.MeetingDate = input.MeetingDate
+	item.AssigneeID = input.AssigneeID
+	item.DueDate = input.DueDate
+
+	if code, msg := validateActionItem(&item); msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	if err := DB.Save(&item).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func ChangeActionItemStatus(c *gin.Context) {
+	id := c.Param("id")
+	var item ActionItem
+	if err := DB.First(&item, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Action item not found"})
+		return
+	}
+
+	var change ActionItemStatusChange
+	if err := c.ShouldBindJSON(&change); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, ok := actionItemTransitions[change.Status]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status must be one of 'open', 'in-progress', 'done' or 'dropped'"})
+		return
+	}
+
+	if !canTransitionActionItem(item.Status, change.Status) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Cannot change status from '" + item.Status + "' to '" + change.Status + "'"})
+		return
+	}
+
+	applyActionItemStatus(&item, change.Status)
+	if err := DB.Save(&item).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func DeleteActionItem(c *gin.Context) {
+	id := c.Param("id")
+	if err := DB.Delete(&ActionItem{}, id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Action item deleted"})
+}
+
+func GetMemberActionItems(c *gin.Context) {
+	id := c.Param("id")
+	var member TeamMember
+	if err := DB.First(&member, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	var items []ActionItem
+	if err := DB.Where("assignee_id = ?", member.ID).Order("due_date IS NULL, due_date, id").Find(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	soon := now.AddDate(0, 0, 7)
+	counts := map[string]int{"open": 0, "in-progress": 0, "done": 0, "dropped": 0}
+	pending := []ActionItem{}
+	overdue := []ActionItem{}
+	dueSoon := []ActionItem{}
+	completed := []ActionItem{}
+
+	for _, item := range items {
+		counts[item.Status]++
+		switch item.Status {
+		case "open", "in-progress":
+			pending = append(pending, item)
+			if item.DueDate != nil && item.DueDate.Before(now) {
+				overdue = append(overdue, item)
+			} else if item.DueDate != nil && item.DueDate.Before(soon) {
+				dueSoon = append(dueSoon, item)
+			}
+		case "done":
+			completed = append(completed, item)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"member_id": member.ID,
+		"counts":    counts,
+		"pending":   pending,
+		"overdue":   overdue,
+		"due_soon":  dueSoon,
+		"completed": completed,
+	})
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateActionItem(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	member := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	DB.Create(&member)
+	feedback := Feedback{Content: "Pair more on reviews", TargetType: "member", TargetID: member.ID}
+	DB.Create(&feedback)
+
+	t.Run("Create action item from feedback", func(t *testing.T) {
+		body := `{"title": "Pair on two reviews", "feedback_id": ` + strconv.Itoa(int(feedback.ID)) + `, "assignee_id": ` + strconv.Itoa(int(member.ID)) + `}`
+		req, _ := http.NewRequest("POST", "/api/v1/action-items", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var response ActionItem
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "open", response.Status)
+		assert.Equal(t, feedback.ID, *response.FeedbackID)
+	})
+
+	t.Run("Create action item without source", func(t *testing.T) {
+		body := `{"title": "Orphan", "assignee_id": ` + strconv.Itoa(int(member.ID)) + `}`
+		req, _ := http.NewRequest("POST", "/api/v1/action-items", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Create action item for non-existing assignee", func(t *testing.T) {
+		body := `{"title": "Follow up", "meeting_title": "1:1", "assignee_id": 999}`
+		req, _ := http.NewRequest("POST", "/api/v1/action-items", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestChangeActionItemStatus(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	member := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	DB.Create(&member)
+	item := ActionItem{Title: "Write ADR", MeetingTitle: "Weekly 1:1", AssigneeID: member.ID}
+	DB.Create(&item)
+
+	changeStatus := func(status string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/api/v1/action-items/"+strconv.Itoa(int(item.ID))+"/status", bytes.NewBufferString(`{"status": "`+status+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Allowed transitions", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, changeStatus("in-progress").Code)
+
+		w := changeStatus("done")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response ActionItem
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "done", response.Status)
+		assert.NotNil(t, response.CompletedAt)
+	})
+
+	t.Run("Forbidden transition", func(t *testing.T) {
+		assert.Equal(t, http.StatusConflict, changeStatus("dropped").Code)
+	})
+
+	t.Run("Unknown status", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, changeStatus("archived").Code)
+
+		req, _ := http.NewRequest("PUT", "/api/v1/action-items/"+strconv.Itoa(int(item.ID)), bytes.NewBufferString(`{"title": "Write ADR", "meeting_title": "Weekly 1:1", "assignee_id": `+strconv.Itoa(int(member.ID))+`, "status": "archived"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestActionItemDashboards(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	member := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	DB.Create(&member)
+	yesterday := time.Now().AddDate(0, 0, -1)
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	DB.Create(&ActionItem{Title: "Late", MeetingTitle: "1:1", AssigneeID: member.ID, DueDate: &yesterday})
+	DB.Create(&ActionItem{Title: "Soon", MeetingTitle: "1:1", AssigneeID: member.ID, DueDate: &tomorrow, Status: "in-progress"})
+	DB.Create(&ActionItem{Title: "Late but done", MeetingTitle: "1:1", AssigneeID: member.ID, DueDate: &yesterday, Status: "done"})
+
+	t.Run("List overdue action items", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/action-items/overdue", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response []ActionItem
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response, 1)
+		assert.Equal(t, "Late", response[0].Title)
+	})
+
+	t.Run("Member dashboard", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/members/"+strconv.Itoa(int(member.ID))+"/action-items", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Counts    map[string]int `json:"counts"`
+			Pending   []ActionItem   `json:"pending"`
+			Overdue   []ActionItem   `json:"overdue"`
+			DueSoon   []ActionItem   `json:"due_soon"`
+			Completed []ActionItem   `json:"completed"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, 1, response.Counts["open"])
+		assert.Equal(t, 1, response.Counts["in-progress"])
+		assert.Equal(t, 1, response.Counts["done"])
+		assert.Len(t, response.Pending, 2)
+		assert.Len(t, response.Overdue, 1)
+		assert.Len(t, response.DueSoon, 1)
+		assert.Len(t, response.Completed, 1)
+	})
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultSilentDays      = 30
+	defaultTopAuthorsLimit = 10
+)
+
+var analyticsBuckets = map[string]map[string]string{
+	"sqlite": {
+		"day":   "date(COALESCE(feedbacks.release_at, feedbacks.created_at))",
+		"week":  "date(COALESCE(feedbacks.release_at, feedbacks.created_at), 'weekday 0', '-6 days')",
+		"month": "strftime('%Y-%m-01', COALESCE(feedbacks.release_at, feedbacks.created_at))",
+	},
+	"mysql": {
+		"day":   "DATE(COALESCE(feedbacks.release_at, feedbacks.created_at))",
+		"week":  "DATE(DATE_SUB(COALESCE(feedbacks.release_at, feedbacks.created_at), INTERVAL WEEKDAY(COALESCE(feedbacks.release_at, feedbacks.created_at)) DAY))",
+		"month": "DATE_FORMAT(COALESCE(feedbacks.release_at, feedbacks.created_at), '%Y-%m-01')",
+	},
+}
+
+type analyticsFilter struct {
+	From   *time.Time
+	To     *time.Time
+	TeamID *uint
+}
+
+func parseAnalyticsTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+func parseAnalyticsFilter(c *gin.Context) (analyticsFilter, error) {
+	var filter analyticsFilter
+	if value := c.Query("from"); value != "" {
+		from, err := parseAnalyticsTime(value)
+		if err != nil {
+			return filter, errors.New("from must be a date (YYYY-MM-DD) or RFC3339 timestamp")
+		}
+		filter.From = &from
+	}
+	if value := c.Query("to"); value != "" {
+		to, err := parseAnalyticsTime(value)
+		if err != nil {
+			return filter, errors.New("to must be a date (YYYY-MM-DD) or RFC3339 timestamp")
+		}
+		filter.To = &to
+	}
+	if value := c.Query("team_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			return filter, errors.New("team_id must be a positive number")
+		}
+		var team Team
+		if err := DB.First(&team, id).Error; err != nil {
+			return filter, errors.New("Team not found")
+		}
+		teamID := team.ID
+		filter.TeamID = &teamID
+	}
+	return filter, nil
+}
+
+func teamMemberIDs(teamID uint) *gorm.DB {
+	return DB.Table("member_teams").Select("team_member_id").Where("team_id = ?", teamID)
+}
+
+func (filter analyticsFilter) feedbackScope(db *gorm.DB) *gorm.DB {
+	if filter.From != nil {
+		db = db.Where("COALESCE(feedbacks.release_at, feedbacks.created_at) >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		db = db.Where("COALESCE(feedbacks.release_at, feedbacks.created_at) < ?", *filter.To)
+	}
+	if filter.TeamID != nil {
+		db = db.Where("(feedbacks.target_type = ? AND feedbacks.target_id = ?) OR (feedbacks.target_type = ? AND feedbacks.target_id IN (?))",
+			"team", *filter.TeamID, "member", teamMemberIDs(*filter.TeamID))
+	}
+	return db
+}
+
+func analyticsFeedback(filter analyticsFilter) *gorm.DB {
+	return DB.Model(&Feedback{}).Scopes(revealedFeedback, publishedFeedback, activeFeedback, filter.feedbackScope)
+}
+
+func bucketExpression(bucket string) (string, bool) {
+	buckets, ok := analyticsBuckets[DB.Dialector.Name()]
+	if !ok {
+		buckets = analyticsBuckets["mysql"]
+	}
+	expression, ok := buckets[bucket]
+	return expression, ok
+}
+
+func GetFeedbackVolume(c *gin.Context) {
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bucket := c.DefaultQuery("bucket", "week")
+	expression, ok := bucketExpression(bucket)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bucket must be 'day', 'week' or 'month'"})
+		return
+	}
+
+	type volumeRow struct {
+		Bucket     string `json:"bucket"`
+		TargetType string `json:"target_type"`
+		TargetID   uint   `json:"target_id"`
+		Count      int64  `json:"count"`
+	}
+
+	rows := []volumeRow{}
+	if err := analyticsFeedback(filter).
+		Select(expression + " AS bucket, feedbacks.target_type, feedbacks.target_id, COUNT(*) AS count").
+		Group("bucket, feedbacks.target_type, feedbacks.target_id").
+		Order("bucket, feedbacks.target_type, feedbacks.target_id").
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"bucket": bucket, "volume": rows})
+}
+
+func GetSilentMembers(c *gin.Context) {
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	days := defaultSilentDays
+	if value := c.Query("days"); value != "" {
+		if days, err = strconv.Atoi(value); err != nil || days <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be a positive number"})
+			return
+		}
+	}
+	since := time.Now().AddDate(0, 0, -days)
+
+	type silentRow struct {
+		ID             uint       `json:"id"`
+		Name           string     `json:"name"`
+		Email          string     `json:"email"`
+		LastFeedbackAt *time.Time `json:"last_feedback_at"`
+	}
+
+	received := analyticsFeedback(analyticsFilter{}).
+		Select("feedbacks.target_id, MAX(COALESCE(feedbacks.release_at, feedbacks.created_at)) AS last_feedback_at").
+		Where("feedbacks.target_type = ?", "member").
+		Group("feedbacks.target_id")
+
+	query := DB.Table("team_members").
+		Select("team_members.id, team_members.name, team_members.email, received.last_feedback_at").
+		Joins("LEFT JOIN (?) AS received ON received.target_id = team_members.id", received).
+		Where("received.last_feedback_at IS NULL OR received.last_feedback_at < ?", since).
+		Where("team_members.status <> ?", "alumni").
+		Order("team_members.name")
+	if filter.TeamID != nil {
+		query = query.Where("team_members.id IN (?)", teamMemberIDs(*filter.TeamID))
+	}
+
+	var raw []struct {
+		ID             uint
+		Name           string
+		Email          string
+		LastFeedbackAt *string
+	}
+	if err := query.Scan(&raw).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	rows := []silentRow{}
+	for _, row := range raw {
+		silent := silentRow{ID: row.ID, Name: row.Name, Email: row.Email}
+		if row.LastFeedbackAt != nil {
+			if t, err := parseDatabaseTime(*row.LastFeedbackAt); err == nil {
+				silent.LastFeedbackAt = &t
+			}
+		}
+		rows = append(rows, silent)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"days": days, "since": since, "members": rows})
+}
+
+func parseDatabaseTime(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02 15:04:05.999999999-07:00", time.RFC3339Nano, "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("unrecognized time " + value)
+}
+
+func GetTopAuthors(c *gin.Context) {
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit := defaultTopAuthorsLimit
+	if value := c.Query("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive number"})
+			return
+		}
+	}
+
+	type authorRow struct {
+		AuthorID uint   `json:"author_id"`
+		Name     string `json:"name"`
+		Count    int64  `json:"count"`
+	}
+
+	rows := []authorRow{}
+	if err := analyticsFeedback(filter).
+		Select("feedbacks.author_id, team_members.name, COUNT(*) AS count").
+		Joins("JOIN team_members ON team_members.id = feedbacks.author_id").
+		Where("feedbacks.author_id IS NOT NULL").
+		Group("feedbacks.author_id, team_members.name").
+		Order("count DESC, team_members.name").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rows)
+}
+
+func GetFeedbackKindRatio(c *gin.Context) {
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var rows []struct {
+		Kind  string
+		Count int64
+	}
+	if err := analyticsFeedback(filter).
+		Select("feedbacks.kind, COUNT(*) AS count").
+		Group("feedbacks.kind").
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	total := int64(0)
+	for _, row := range rows {
+		total += row.Count
+	}
+
+	kinds := gin.H{}
+	for kind := range validFeedbackKinds {
+		kinds[kind] = gin.H{"count": 0, "ratio": 0.0}
+	}
+	for _, row := range rows {
+		kinds[row.Kind] = gin.H{"count": row.Count, "ratio": math.Round(float64(row.Count)/float64(total)*1000) / 1000}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"total": total, "kinds": kinds})
+}
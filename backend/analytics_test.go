@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedbackAnalytics(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	alice := TeamMember{Name: "Alice", Email: "alice@example.com"}
+	bob := TeamMember{Name: "Bob", Email: "bob@example.com"}
+	carol := TeamMember{Name: "Carol", Email: "carol@example.com"}
+	dave := TeamMember{Name: "Dave", Email: "dave@example.com", Status: "alumni"}
+	DB.Create(&alice)
+	DB.Create(&bob)
+	DB.Create(&carol)
+	DB.Create(&dave)
+	platform := Team{Name: "Platform", Members: []TeamMember{alice, bob, dave}}
+	design := Team{Name: "Design", Members: []TeamMember{carol}}
+	DB.Create(&platform)
+	DB.Create(&design)
+
+	monday := time.Date(2026, 9, 7, 10, 0, 0, 0, time.UTC)
+	old := time.Now().AddDate(0, 0, -60)
+	feedbacks := []Feedback{
+		{Content: "a", Kind: "kudos", TargetType: "member", TargetID: alice.ID, AuthorID: &bob.ID, CreatedAt: monday},
+		{Content: "b", Kind: "kudos", TargetType: "member", TargetID: alice.ID, AuthorID: &bob.ID, CreatedAt: monday.AddDate(0, 0, 3)},
+		{Content: "c", Kind: "constructive", TargetType: "member", TargetID: alice.ID, AuthorID: &carol.ID, CreatedAt: monday.AddDate(0, 0, 7)},
+		{Content: "d", Kind: "observation", TargetType: "member", TargetID: bob.ID, AuthorID: &alice.ID, CreatedAt: old},
+		{Content: "e", Kind: "kudos", TargetType: "team", TargetID: design.ID, AuthorID: &alice.ID, CreatedAt: time.Now()},
+	}
+	for i := range feedbacks {
+		DB.Create(&feedbacks[i])
+	}
+
+	get := func(url string, target interface{}) int {
+		req, _ := http.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		json.Unmarshal(w.Body.Bytes(), target)
+		return w.Code
+	}
+
+	t.Run("Weekly volume per target", func(t *testing.T) {
+		var response struct {
+			Volume []struct {
+				Bucket     string `json:"bucket"`
+				TargetType string `json:"target_type"`
+				TargetID   uint   `json:"target_id"`
+				Count      int64  `json:"count"`
+			} `json:"volume"`
+		}
+		code := get("/api/v1/analytics/feedback-volume?from=2026-09-01&to=2026-10-01", &response)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, response.Volume, 2)
+		assert.Equal(t, "2026-09-07", response.Volume[0].Bucket)
+		assert.Equal(t, int64(2), response.Volume[0].Count)
+		assert.Equal(t, "2026-09-14", response.Volume[1].Bucket)
+	})
+
+	t.Run("Scheduled feedback is bucketed by release time", func(t *testing.T) {
+		releaseAt := monday.AddDate(0, 0, 14)
+		scheduled := Feedback{Content: "f", Kind: "kudos", TargetType: "member", TargetID: alice.ID, AuthorID: &carol.ID, CreatedAt: monday.AddDate(0, 0, -30), ReleaseAt: &releaseAt}
+		DB.Create(&scheduled)
+		defer DB.Delete(&scheduled)
+
+		var response struct {
+			Volume []struct {
+				Bucket string `json:"bucket"`
+			} `json:"volume"`
+		}
+		code := get("/api/v1/analytics/feedback-volume?from=2026-09-01&to=2026-10-01", &response)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, response.Volume, 3)
+		assert.Equal(t, "2026-09-21", response.Volume[2].Bucket)
+	})
+
+	t.Run("Reject unknown bucket", func(t *testing.T) {
+		var response map[string]interface{}
+		assert.Equal(t, http.StatusBadRequest, get("/api/v1/analytics/feedback-volume?bucket=year", &response))
+	})
+
+	t.Run("Silent members in team", func(t *testing.T) {
+		var response struct {
+			Members []struct {
+				ID             uint       `json:"id"`
+				LastFeedbackAt *time.Time `json:"last_feedback_at"`
+			} `json:"members"`
+		}
+		code := get("/api/v1/analytics/silent-members?team_id="+strconv.Itoa(int(platform.ID)), &response)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, response.Members, 2)
+		for _, member := range response.Members {
+			assert.NotNil(t, member.LastFeedbackAt)
+		}
+	})
+
+	t.Run("Top authors", func(t *testing.T) {
+		var response []struct {
+			AuthorID uint  `json:"author_id"`
+			Count    int64 `json:"count"`
+		}
+		code := get("/api/v1/analytics/top-authors?limit=2", &response)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Len(t, response, 2)
+		assert.Equal(t, int64(2), response[0].Count)
+	})
+
+	t.Run("Kind ratio filtered by team", func(t *testing.T) {
+		var response struct {
+			Total int64 `json:"total"`
+			Kinds map[string]struct {
+				Count int64   `json:"count"`
+				Ratio float64 `json:"ratio"`
+			} `json:"kinds"`
+		}
+		code := get("/api/v1/analytics/feedback-kinds?team_id="+strconv.Itoa(int(platform.ID)), &response)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, int64(4), response.Total)
+		assert.Equal(t, int64(2), response.Kinds["kudos"].Count)
+		assert.Equal(t, 0.5, response.Kinds["kudos"].Ratio)
+	})
+}
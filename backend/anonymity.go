@@ -0,0 +1,53 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var anonymousFeedbackMinContributors = getEnvInt("ANONYMOUS_FEEDBACK_MIN_CONTRIBUTORS", 3)
+
+func anonymityKey() []byte {
+	return []byte(os.Getenv("FEEDBACK_ANONYMITY_KEY"))
+}
+
+func contributorHash(targetType string, targetID, authorID uint) string {
+	mac := hmac.New(sha256.New, anonymityKey())
+	fmt.Fprintf(mac, "%s:%d:%d", targetType, targetID, authorID)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func coarsenTimestamp(t time.Time) time.Time {
+	day := t.UTC().Truncate(24 * time.Hour)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func anonymize(feedback *Feedback) {
+	feedback.ContributorHash = contributorHash(feedback.TargetType, feedback.TargetID, *feedback.AuthorID)
+	feedback.AuthorID = nil
+	feedback.CreatedAt = coarsenTimestamp(time.Now())
+	feedback.UpdatedAt = feedback.CreatedAt
+	for i := range feedback.Competencies {
+		feedback.Competencies[i].CreatedAt = feedback.CreatedAt
+	}
+}
+
+func revealedTargets(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&Feedback{}).
+		Scopes(publishedFeedback, activeFeedback).
+		Select("target_type, target_id").
+		Where("anonymous = ?", true).
+		Group("target_type, target_id").
+		Having("COUNT(DISTINCT contributor_hash) >= ?", anonymousFeedbackMinContributors)
+}
+
+func revealedFeedback(db *gorm.DB) *gorm.DB {
+	return db.Where("anonymous = ? OR (target_type, target_id) IN (?)", false, revealedTargets(db))
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnonymousFeedback(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+	t.Setenv("FEEDBACK_ANONYMITY_KEY", "test-anonymity-key")
+
+	router := setupTestRouter()
+
+	target := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	DB.Create(&target)
+	authors := make([]TeamMember, anonymousFeedbackMinContributors)
+	for i := range authors {
+		authors[i] = TeamMember{Name: "Peer " + strconv.Itoa(i), Email: "peer" + strconv.Itoa(i) + "@example.com"}
+		DB.Create(&authors[i])
+	}
+
+	submit := func(authorID uint, content string) *httptest.ResponseRecorder {
+		body := `{"content": "` + content + `", "target_type": "member", "target_id": ` + strconv.Itoa(int(target.ID)) + `, "anonymous": true, "author_id": ` + strconv.Itoa(int(authorID)) + `}`
+		req, _ := http.NewRequest("POST", "/api/v1/feedback", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	listForTarget := func() []Feedback {
+		req, _ := http.NewRequest("GET", "/api/v1/feedback?target_type=member&target_id="+strconv.Itoa(int(target.ID)), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var feedbacks []Feedback
+		json.Unmarshal(w.Body.Bytes(), &feedbacks)
+		return feedbacks
+	}
+
+	t.Run("Author is not stored and timestamps are coarsened", func(t *testing.T) {
+		w := submit(authors[0].ID, "Speaks over others in meetings")
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var stored Feedback
+		DB.Last(&stored)
+		assert.Nil(t, stored.AuthorID)
+		assert.NotEmpty(t, stored.ContributorHash)
+		assert.Equal(t, coarsenTimestamp(time.Now()), stored.CreatedAt.UTC())
+		assert.Equal(t, time.Monday, stored.CreatedAt.UTC().Weekday())
+	})
+
+	t.Run("Client supplied timestamps and state are ignored", func(t *testing.T) {
+		body := `{"content": "Backdated", "target_type": "member", "target_id": ` + strconv.Itoa(int(target.ID)) + `,
+			"created_at": "2020-01-01T10:00:00Z", "archived_at": "2020-01-02T10:00:00Z",
+			"acknowledged_at": "2020-01-03T10:00:00Z", "acknowledged_by": ` + strconv.Itoa(int(target.ID)) + `}`
+		req, _ := http.NewRequest("POST", "/api/v1/feedback", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var stored Feedback
+		DB.Last(&stored)
+		assert.Equal(t, "Backdated", stored.Content)
+		assert.Nil(t, stored.ArchivedAt)
+		assert.Nil(t, stored.AcknowledgedAt)
+		assert.Nil(t, stored.AcknowledgedBy)
+		assert.WithinDuration(t, time.Now(), stored.CreatedAt, time.Minute)
+		DB.Delete(&stored)
+	})
+
+	t.Run("Anonymous feedback requires an author", func(t *testing.T) {
+		body := `{"content": "Nope", "target_type": "member", "target_id": ` + strconv.Itoa(int(target.ID)) + `, "anonymous": true}`
+		req, _ := http.NewRequest("POST", "/api/v1/feedback", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Anonymous feedback requires an anonymity key", func(t *testing.T) {
+		t.Setenv("FEEDBACK_ANONYMITY_KEY", "")
+		w := submit(authors[0].ID, "Unkeyed")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("Contributor hashes differ between targets", func(t *testing.T) {
+		assert.NotEqual(t, contributorHash("member", 1, authors[0].ID), contributorHash("member", 2, authors[0].ID))
+		assert.NotEqual(t, contributorHash("member", 1, authors[0].ID), contributorHash("team", 1, authors[0].ID))
+	})
+
+	t.Run("Hidden until enough distinct contributors", func(t *testing.T) {
+		DB.Create(&Feedback{Content: "Attributed", TargetType: "member", TargetID: target.ID})
+		for i := 0; i < 3; i++ {
+			submit(authors[0].ID, "Same author again")
+		}
+		for i := 1; i < len(authors)-1; i++ {
+			submit(authors[i].ID, "Another voice")
+		}
+
+		feedbacks := listForTarget()
+		assert.Len(t, feedbacks, 1)
+		assert.Equal(t, "Attributed", feedbacks[0].Content)
+
+		var hidden Feedback
+		DB.Where("anonymous = ?", true).First(&hidden)
+		req, _ := http.NewRequest("GET", "/api/v1/feedback/"+strconv.Itoa(int(hidden.ID)), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Archived anonymous feedback does not count toward the threshold", func(t *testing.T) {
+		archivedAt := time.Now()
+		archived := Feedback{Content: "Archived voice", TargetType: "member", TargetID: target.ID, Anonymous: true, ContributorHash: "archived-contributor", ArchivedAt: &archivedAt}
+		DB.Create(&archived)
+		defer DB.Delete(&archived)
+
+		assert.Len(t, listForTarget(), 1)
+	})
+
+	t.Run("Revealed once threshold is met", func(t *testing.T) {
+		submit(authors[len(authors)-1].ID, "Final voice")
+
+		feedbacks := listForTarget()
+		assert.Len(t, feedbacks, 1+3+len(authors))
+		for _, feedback := range feedbacks {
+			if feedback.Anonymous {
+				assert.Nil(t, feedback.AuthorID)
+			}
+		}
+	})
+
+	t.Run("Approval and competency timestamps are coarsened", func(t *testing.T) {
+		competency := Competency{Name: "Collaboration"}
+		DB.Create(&competency)
+		DB.Create(&ApprovalPolicy{Kind: "constructive", ApproverID: &authors[1].ID})
+
+		body := `{"content": "Needs review", "kind": "constructive", "target_type": "member", "target_id": ` + strconv.Itoa(int(target.ID)) + `,
+			"anonymous": true, "author_id": ` + strconv.Itoa(int(authors[0].ID)) + `,
+			"competencies": [{"competency_id": ` + strconv.Itoa(int(competency.ID)) + `, "rating": 3}]}`
+		req, _ := http.NewRequest("POST", "/api/v1/feedback", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		coarsened := coarsenTimestamp(time.Now())
+		var stored Feedback
+		DB.Last(&stored)
+		var rating CompetencyRating
+		DB.Where("feedback_id = ?", stored.ID).First(&rating)
+		assert.Equal(t, coarsened, rating.CreatedAt.UTC())
+
+		var approval FeedbackApproval
+		DB.Where("feedback_id = ?", stored.ID).First(&approval)
+		assert.Equal(t, coarsened, approval.CreatedAt.UTC())
+		assert.Equal(t, coarsened, approval.UpdatedAt.UTC())
+
+		var event FeedbackApprovalEvent
+		DB.Where("feedback_approval_id = ?", approval.ID).First(&event)
+		assert.Equal(t, coarsened, event.CreatedAt.UTC())
+	})
+}
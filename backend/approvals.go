@@ -0,0 +1,439 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var feedbackApprovalTransitions = map[string][]string{
+	"pending":           {"approved", "rejected", "changes_requested"},
+	"changes_requested": {"pending", "rejected"},
+}
+
+var approvalDecisions = map[string]string{
+	"approve":      "approved",
+	"reject":       "rejected",
+	"suggest_edit": "changes_requested",
+}
+
+func canTransitionFeedbackApproval(from, to string) bool {
+	for _, next := range feedbackApprovalTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func feedbackApprovers(feedback *Feedback, policy *ApprovalPolicy) []uint {
+	seen := map[uint]bool{}
+	for _, leadID := range teamLeadIDs(feedback.TargetID) {
+		seen[leadID] = true
+	}
+	if manager := memberManagerID(feedback.TargetID); manager != nil {
+		seen[*manager] = true
+	}
+	if len(seen) == 0 && policy.ApproverID != nil {
+		seen[*policy.ApproverID] = true
+	}
+	if feedback.AuthorID != nil {
+		delete(seen, *feedback.AuthorID)
+	}
+	delete(seen, feedback.TargetID)
+
+	approvers := []uint{}
+	for id := range seen {
+		approvers = append(approvers, id)
+	}
+	sort.Slice(approvers, func(i, j int) bool { return approvers[i] < approvers[j] })
+	return approvers
+}
+
+func isApprover(approval *FeedbackApproval, memberID uint) bool {
+	for _, id := range approval.Approvers {
+		if id == memberID {
+			return true
+		}
+	}
+	return false
+}
+
+func removeApprover(tx *gorm.DB, memberID uint) error {
+	var approvals []FeedbackApproval
+	if err := tx.Where("status IN ?", []string{"pending", "changes_requested"}).Find(&approvals).Error; err != nil {
+		return err
+	}
+	for i := range approvals {
+		if !isApprover(&approvals[i], memberID) {
+			continue
+		}
+		remaining := []uint{}
+		for _, id := range approvals[i].Approvers {
+			if id != memberID {
+				remaining = append(remaining, id)
+			}
+		}
+		approvals[i].Approvers = remaining
+		if err := tx.Model(&approvals[i]).Select("approvers").Updates(&approvals[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func approvalPolicyFor(feedback *Feedback) *ApprovalPolicy {
+	if feedback.TargetType != "member" {
+		return nil
+	}
+	var policy ApprovalPolicy
+	if err := DB.Where("kind = ?", feedback.Kind).First(&policy).Error; err != nil {
+		return nil
+	}
+	return &policy
+}
+
+func prepareFeedbackApproval(feedback *Feedback) (*FeedbackApproval, string) {
+	if feedback.Status == "draft" {
+		return nil, ""
+	}
+	policy := approvalPolicyFor(feedback)
+	if policy == nil {
+		return nil, ""
+	}
+	approvers := feedbackApprovers(feedback, policy)
+	if len(approvers) == 0 {
+		return nil, "No approver is available for " + feedback.Kind + " feedback about this member"
+	}
+	approval := &FeedbackApproval{Status: "pending", RequestedStatus: feedback.Status, Approvers: approvers}
+	feedback.Status = "pending_approval"
+	return approval, ""
+}
+
+func feedbackEditConflict(feedback *Feedback) string {
+	switch feedback.Status {
+	case "pending_approval":
+		return "Feedback awaiting approval cannot be edited, resubmit it instead"
+	case "rejected":
+		return "Rejected feedback cannot be edited"
+	}
+	var count int64
+	DB.Model(&FeedbackApproval{}).Where("feedback_id = ? AND status = ?", feedback.ID, "approved").Count(&count)
+	if count > 0 {
+		return "Approved feedback cannot be edited"
+	}
+	return ""
+}
+
+func feedbackWordingChanged(before, after *Feedback) bool {
+	return before.Content != after.Content || before.Kind != after.Kind ||
+		before.Situation != after.Situation || before.Behavior != after.Behavior || before.Impact != after.Impact
+}
+
+func recordApprovalEvent(tx *gorm.DB, approval *FeedbackApproval, feedback *Feedback, from string, actorID *uint, comment string) error {
+	event := FeedbackApprovalEvent{
+		FeedbackApprovalID: approval.ID,
+		FromStatus:         from,
+		ToStatus:           approval.Status,
+		ActorID:            actorID,
+		Comment:            comment,
+	}
+	if feedback.Anonymous {
+		event.CreatedAt = coarsenTimestamp(time.Now())
+	}
+	return tx.Create(&event).Error
+}
+
+func notifyApprovers(tx *gorm.DB, approval *FeedbackApproval) error {
+	for _, approverID := range approval.Approvers {
+		if err := notify(tx, approverID, "feedback_approval_requested", "Feedback is waiting for your approval"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createFeedbackApproval(tx *gorm.DB, feedback *Feedback, approval *FeedbackApproval) error {
+	approval.FeedbackID = feedback.ID
+	if feedback.Anonymous {
+		approval.CreatedAt = feedback.CreatedAt
+		approval.UpdatedAt = feedback.CreatedAt
+	}
+	if err := tx.Omit("Events").Create(approval).Error; err != nil {
+		return err
+	}
+	if err := recordApprovalEvent(tx, approval, feedback, "", feedback.AuthorID, ""); err != nil {
+		return err
+	}
+	return notifyApprovers(tx, approval)
+}
+
+func loadFeedbackApproval(feedbackID string) (*FeedbackApproval, error) {
+	var approval FeedbackApproval
+	err := DB.Preload("Events", func(db *gorm.DB) *gorm.DB { return db.Order("created_at, id") }).
+		Where("feedback_id = ?", feedbackID).First(&approval).Error
+	return &approval, err
+}
+
+func CreateApprovalPolicy(c *gin.Context) {
+	var policy ApprovalPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy.Kind = strings.ToLower(strings.TrimSpace(policy.Kind))
+	if !validFeedbackKinds[policy.Kind] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Kind must be 'kudos', 'constructive' or 'observation'"})
+		return
+	}
+
+	if policy.ApproverID != nil {
+		var approver TeamMember
+		if err := DB.First(&approver, *policy.ApproverID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Approver not found"})
+			return
+		}
+	}
+
+	var count int64
+	DB.Model(&ApprovalPolicy{}).Where("kind = ?", policy.Kind).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "An approval policy already exists for this kind"})
+		return
+	}
+
+	policy.ID = 0
+	if err := DB.Create(&policy).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, policy)
+}
+
+func GetApprovalPolicies(c *gin.Context) {
+	var policies []ApprovalPolicy
+	if err := DB.Order("kind").Find(&policies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, policies)
+}
+
+func DeleteApprovalPolicy(c *gin.Context) {
+	id := c.Param("id")
+	if err := DB.Delete(&ApprovalPolicy{}, id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Approval policy deleted"})
+}
+
+func GetFeedbackApproval(c *gin.Context) {
+	approval, err := loadFeedbackApproval(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback approval not found"})
+		return
+	}
+	c.JSON(http.StatusOK, approval)
+}
+
+func DecideFeedbackApproval(c *gin.Context) {
+	approval, err := loadFeedbackApproval(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback approval not found"})
+		return
+	}
+
+	var decision FeedbackApprovalDecision
+	if err := c.ShouldBindJSON(&decision); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status, ok := approvalDecisions[decision.Decision]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Decision must be 'approve', 'reject' or 'suggest_edit'"})
+		return
+	}
+
+	if status == "changes_requested" && strings.TrimSpace(decision.SuggestedContent) == "" && strings.TrimSpace(decision.Comment) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Suggesting an edit requires a comment or suggested content"})
+		return
+	}
+
+	if !isApprover(approval, decision.ApproverID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Member is not an approver for this feedback"})
+		return
+	}
+
+	if approval.Status != "pending" || !canTransitionFeedbackApproval(approval.Status, status) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Feedback approval is not pending"})
+		return
+	}
+
+	var feedback Feedback
+	if err := DB.First(&feedback, approval.FeedbackID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback not found"})
+		return
+	}
+
+	from := approval.Status
+	now := time.Now()
+	approval.Status = status
+	approval.DecidedBy = &decision.ApproverID
+	approval.DecidedAt = &now
+	approval.Comment = decision.Comment
+	approval.SuggestedContent = strings.TrimSpace(decision.SuggestedContent)
+
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Events").Save(approval).Error; err != nil {
+			return err
+		}
+		if err := recordApprovalEvent(tx, approval, &feedback, from, &decision.ApproverID, decision.Comment); err != nil {
+			return err
+		}
+
+		switch status {
+		case "approved":
+			if approval.RequestedStatus == "scheduled" {
+				feedback.Status = "scheduled"
+				if err := tx.Model(&Feedback{ID: feedback.ID}).Update("status", feedback.Status).Error; err != nil {
+					return err
+				}
+			} else if released, err := releaseFeedback(tx, &feedback, feedback.Status, now); err != nil {
+				return err
+			} else if !released {
+				return errFeedbackStatusChanged
+			}
+		case "rejected":
+			feedback.Status = "rejected"
+			if err := tx.Model(&Feedback{ID: feedback.ID}).Update("status", feedback.Status).Error; err != nil {
+				return err
+			}
+		}
+
+		if feedback.AuthorID == nil {
+			return nil
+		}
+		return notify(tx, *feedback.AuthorID, "feedback_approval_"+status, "Your feedback was reviewed: "+strings.ReplaceAll(status, "_", " "))
+	})
+	if errors.Is(err, errFeedbackStatusChanged) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	approval, _ = loadFeedbackApproval(c.Param("id"))
+	c.JSON(http.StatusOK, approval)
+}
+
+func ResubmitFeedback(c *gin.Context) {
+	approval, err := loadFeedbackApproval(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback approval not found"})
+		return
+	}
+
+	var resubmission FeedbackResubmission
+	if err := c.ShouldBindJSON(&resubmission); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var feedback Feedback
+	if err := DB.First(&feedback, approval.FeedbackID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback not found"})
+		return
+	}
+
+	if feedback.AuthorID == nil || *feedback.AuthorID != resubmission.AuthorID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the author can resubmit feedback"})
+		return
+	}
+
+	if !canTransitionFeedbackApproval(approval.Status, "pending") {
+		c.JSON(http.StatusConflict, gin.H{"error": "Feedback approval has no requested changes"})
+		return
+	}
+
+	content := strings.TrimSpace(resubmission.Content)
+	if content == "" {
+		content = approval.SuggestedContent
+	}
+	if content == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Content is required"})
+		return
+	}
+
+	from := approval.Status
+	approval.Status = "pending"
+	approval.DecidedBy = nil
+	approval.DecidedAt = nil
+	feedback.Content = content
+	scoreFeedback(&feedback)
+
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Feedback{ID: feedback.ID}).Updates(map[string]interface{}{
+			"content":         feedback.Content,
+			"sentiment_score": feedback.SentimentScore,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit("Events").Save(approval).Error; err != nil {
+			return err
+		}
+		if err := recordApprovalEvent(tx, approval, &feedback, from, &resubmission.AuthorID, ""); err != nil {
+			return err
+		}
+		return notifyApprovers(tx, approval)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	approval, _ = loadFeedbackApproval(c.Param("id"))
+	c.JSON(http.StatusOK, approval)
+}
+
+func GetPendingApprovals(c *gin.Context) {
+	id := c.Param("id")
+	var member TeamMember
+	if err := DB.First(&member, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	var approvals []FeedbackApproval
+	if err := DB.Where("status = ?", "pending").Order("created_at, id").Find(&approvals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	feedbackIDs := []uint{}
+	for i := range approvals {
+		if isApprover(&approvals[i], member.ID) {
+			feedbackIDs = append(feedbackIDs, approvals[i].FeedbackID)
+		}
+	}
+
+	feedbacks := []Feedback{}
+	if len(feedbackIDs) > 0 {
+		if err := DB.Where("id IN ?", feedbackIDs).Order("created_at, id").Find(&feedbacks).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, feedbacks)
+}
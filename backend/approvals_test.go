@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedbackApprovalWorkflow(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	author := TeamMember{Name: "Author", Email: "author@example.com"}
+	target := TeamMember{Name: "Target", Email: "target@example.com"}
+	approver := TeamMember{Name: "Approver", Email: "approver@example.com"}
+	DB.Create(&author)
+	DB.Create(&target)
+	DB.Create(&approver)
+
+	post := func(url, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", url, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	visible := func() int {
+		req, _ := http.NewRequest("GET", "/api/v1/feedback?target_type=member&target_id="+strconv.Itoa(int(target.ID)), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var feedbacks []Feedback
+		json.Unmarshal(w.Body.Bytes(), &feedbacks)
+		return len(feedbacks)
+	}
+	countNotifications := func(memberID uint, kind string) int {
+		var count int64
+		DB.Model(&Notification{}).Where("member_id = ? AND kind = ?", memberID, kind).Count(&count)
+		return int(count)
+	}
+
+	w := post("/api/v1/approval-policies", `{"kind": "constructive", "approver_id": `+strconv.Itoa(int(approver.ID))+`}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	feedbackBody := `{"target_type": "member", "target_id": ` + strconv.Itoa(int(target.ID)) + `, "author_id": ` + strconv.Itoa(int(author.ID)) + `, "kind": "constructive", "content": "Your updates are unclear"}`
+	var feedback Feedback
+
+	t.Run("Constructive feedback waits for approval", func(t *testing.T) {
+		w := post("/api/v1/feedback", feedbackBody)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &feedback)
+
+		assert.Equal(t, "pending_approval", feedback.Status)
+		assert.Equal(t, 0, visible())
+		assert.Equal(t, 1, countNotifications(approver.ID, "feedback_approval_requested"))
+
+		req, _ := http.NewRequest("GET", "/api/v1/members/"+strconv.Itoa(int(approver.ID))+"/pending-approvals", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var pending []Feedback
+		json.Unmarshal(w.Body.Bytes(), &pending)
+		assert.Len(t, pending, 1)
+	})
+
+	approvalURL := func() string { return "/api/v1/feedback/" + strconv.Itoa(int(feedback.ID)) + "/approval" }
+
+	t.Run("Kudos skips approval", func(t *testing.T) {
+		w := post("/api/v1/feedback", `{"target_type": "member", "target_id": `+strconv.Itoa(int(target.ID))+`, "kind": "kudos", "content": "Great job"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, 1, visible())
+	})
+
+	t.Run("Non approver cannot decide", func(t *testing.T) {
+		w := post(approvalURL(), `{"approver_id": `+strconv.Itoa(int(author.ID))+`, "decision": "approve"}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Suggest edit then resubmit", func(t *testing.T) {
+		w := post(approvalURL(), `{"approver_id": `+strconv.Itoa(int(approver.ID))+`, "decision": "suggest_edit", "suggested_content": "Weekly updates would help the team plan"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 1, countNotifications(author.ID, "feedback_approval_changes_requested"))
+
+		w = post(approvalURL(), `{"approver_id": `+strconv.Itoa(int(approver.ID))+`, "decision": "approve"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = post("/api/v1/feedback/"+strconv.Itoa(int(feedback.ID))+"/resubmit", `{"author_id": `+strconv.Itoa(int(author.ID))+`}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var stored Feedback
+		DB.First(&stored, feedback.ID)
+		assert.Equal(t, "Weekly updates would help the team plan", stored.Content)
+	})
+
+	t.Run("Approval delivers feedback and records history", func(t *testing.T) {
+		w := post(approvalURL(), `{"approver_id": `+strconv.Itoa(int(approver.ID))+`, "decision": "approve"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var approval FeedbackApproval
+		json.Unmarshal(w.Body.Bytes(), &approval)
+		assert.Equal(t, "approved", approval.Status)
+		assert.Len(t, approval.Events, 4)
+		assert.Equal(t, 2, visible())
+		assert.Equal(t, 1, countNotifications(author.ID, "feedback_approval_approved"))
+		assert.Equal(t, 1, countNotifications(target.ID, "feedback_released"))
+	})
+
+	t.Run("Rejected feedback stays hidden", func(t *testing.T) {
+		w := post("/api/v1/feedback", feedbackBody)
+		var rejected Feedback
+		json.Unmarshal(w.Body.Bytes(), &rejected)
+
+		w = post("/api/v1/feedback/"+strconv.Itoa(int(rejected.ID))+"/approval", `{"approver_id": `+strconv.Itoa(int(approver.ID))+`, "decision": "reject", "comment": "Discuss in person"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var stored Feedback
+		DB.First(&stored, rejected.ID)
+		assert.Equal(t, "rejected", stored.Status)
+		assert.Equal(t, 2, visible())
+	})
+
+	t.Run("Feedback without a resolvable approver is refused", func(t *testing.T) {
+		body := `{"target_type": "member", "target_id": ` + strconv.Itoa(int(target.ID)) + `, "author_id": ` + strconv.Itoa(int(approver.ID)) + `, "kind": "constructive", "content": "Slow reviews"}`
+		w := post("/api/v1/feedback", body)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestFeedbackEditsRespectApproval(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	author := TeamMember{Name: "Author", Email: "author@example.com"}
+	target := TeamMember{Name: "Target", Email: "target@example.com"}
+	approver := TeamMember{Name: "Approver", Email: "approver@example.com"}
+	DB.Create(&author)
+	DB.Create(&target)
+	DB.Create(&approver)
+	DB.Create(&ApprovalPolicy{Kind: "constructive", ApproverID: &approver.ID})
+
+	create := func(kind, status string) Feedback {
+		w := performRequest(router, "POST", "/api/v1/feedback", `{"target_type": "member", "target_id": `+strconv.Itoa(int(target.ID))+`, "author_id": `+strconv.Itoa(int(author.ID))+`, "kind": "`+kind+`", "status": "`+status+`", "content": "Original"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var feedback Feedback
+		json.Unmarshal(w.Body.Bytes(), &feedback)
+		return feedback
+	}
+	feedbackURL := func(feedback Feedback) string { return "/api/v1/feedback/" + strconv.Itoa(int(feedback.ID)) }
+
+	t.Run("Pending feedback cannot be edited", func(t *testing.T) {
+		feedback := create("constructive", "published")
+		assert.Equal(t, "pending_approval", feedback.Status)
+
+		w := performRequest(router, "PUT", feedbackURL(feedback), `{"kind": "constructive", "content": "Rewritten"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Approved feedback cannot be edited", func(t *testing.T) {
+		feedback := create("constructive", "published")
+		w := performRequest(router, "POST", feedbackURL(feedback)+"/approval", `{"approver_id": `+strconv.Itoa(int(approver.ID))+`, "decision": "approve"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = performRequest(router, "PUT", feedbackURL(feedback), `{"kind": "kudos", "content": "Rewritten"}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		var stored Feedback
+		DB.First(&stored, feedback.ID)
+		assert.Equal(t, "Original", stored.Content)
+	})
+
+	t.Run("Switching to a covered kind requires approval", func(t *testing.T) {
+		feedback := create("kudos", "published")
+		assert.Equal(t, "published", feedback.Status)
+
+		w := performRequest(router, "PUT", feedbackURL(feedback), `{"kind": "constructive", "content": "Actually, this needs work"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var updated Feedback
+		json.Unmarshal(w.Body.Bytes(), &updated)
+		assert.Equal(t, "pending_approval", updated.Status)
+
+		approval, err := loadFeedbackApproval(strconv.Itoa(int(feedback.ID)))
+		assert.NoError(t, err)
+		assert.Equal(t, "published", approval.RequestedStatus)
+		assert.Equal(t, []uint{approver.ID}, approval.Approvers)
+	})
+
+	t.Run("Editing scheduled feedback of a covered kind requires approval", func(t *testing.T) {
+		feedback := create("observation", "draft")
+		w := performRequest(router, "PUT", feedbackURL(feedback), `{"kind": "constructive", "content": "Still a draft"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var updated Feedback
+		json.Unmarshal(w.Body.Bytes(), &updated)
+		assert.Equal(t, "draft", updated.Status)
+
+		DB.Model(&Feedback{ID: feedback.ID}).Update("status", "scheduled")
+		w = performRequest(router, "PUT", feedbackURL(feedback), `{"kind": "constructive", "content": "Rewritten before release"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &updated)
+		assert.Equal(t, "pending_approval", updated.Status)
+	})
+
+	t.Run("Rating changes alone do not trigger approval", func(t *testing.T) {
+		feedback := create("kudos", "published")
+		w := performRequest(router, "PUT", feedbackURL(feedback), `{"kind": "kudos", "content": "Original", "rating": 5}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var updated Feedback
+		json.Unmarshal(w.Body.Bytes(), &updated)
+		assert.Equal(t, "published", updated.Status)
+	})
+}
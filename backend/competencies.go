@@ -0,0 +1,313 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func competencyLevelRange(competency Competency) (int, int) {
+	if len(competency.Levels) == 0 {
+		return 1, 5
+	}
+	low, high := competency.Levels[0].Level, competency.Levels[0].Level
+	for _, level := range competency.Levels {
+		if level.Level < low {
+			low = level.Level
+		}
+		if level.Level > high {
+			high = level.Level
+		}
+	}
+	return low, high
+}
+
+func validateCompetencyLevels(competency *Competency) string {
+	if competency.Name == "" {
+		return "Name is required"
+	}
+	seen := map[int]bool{}
+	for i := range competency.Levels {
+		level := &competency.Levels[i]
+		level.ID = 0
+		level.CompetencyID = competency.ID
+		if level.Name == "" {
+			return "Level name is required"
+		}
+		if level.Level <= 0 || seen[level.Level] {
+			return "Levels must be unique positive numbers"
+		}
+		seen[level.Level] = true
+	}
+	return ""
+}
+
+func validateCompetencyRatings(ratings []CompetencyRating, memberID uint) string {
+	if len(ratings) == 0 {
+		return ""
+	}
+
+	ids := []uint{}
+	for _, rating := range ratings {
+		ids = append(ids, rating.CompetencyID)
+	}
+
+	var competencies []Competency
+	if err := DB.Preload("Levels").Find(&competencies, ids).Error; err != nil {
+		return err.Error()
+	}
+	byID := map[uint]Competency{}
+	for _, competency := range competencies {
+		byID[competency.ID] = competency
+	}
+
+	seen := map[uint]bool{}
+	for i := range ratings {
+		rating := &ratings[i]
+		competency, ok := byID[rating.CompetencyID]
+		if !ok {
+			return "Competency " + strconv.Itoa(int(rating.CompetencyID)) + " not found"
+		}
+		if seen[rating.CompetencyID] {
+			return "Competency " + competency.Name + " is rated more than once"
+		}
+		seen[rating.CompetencyID] = true
+		low, high := competencyLevelRange(competency)
+		if rating.Rating < low || rating.Rating > high {
+			return "Rating for " + competency.Name + " must be between " + strconv.Itoa(low) + " and " + strconv.Itoa(high)
+		}
+		rating.ID = 0
+		rating.MemberID = memberID
+		rating.FeedbackID = nil
+		rating.ReviewID = nil
+	}
+	return ""
+}
+
+func CreateCompetency(c *gin.Context) {
+	var competency Competency
+	if err := c.ShouldBindJSON(&competency); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	competency.ID = 0
+	if msg := validateCompetencyLevels(&competency); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	if err := DB.Create(&competency).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, competency)
+}
+
+func GetCompetencies(c *gin.Context) {
+	var competencies []Competency
+	if err := DB.Preload("Levels", func(db *gorm.DB) *gorm.DB { return db.Order("level") }).Order("name").Find(&competencies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, competencies)
+}
+
+func GetCompetency(c *gin.Context) {
+	id := c.Param("id")
+	var competency Competency
+	if err := DB.Preload("Levels", func(db *gorm.DB) *gorm.DB { return db.Order("level") }).First(&competency, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Competency not found"})
+		return
+	}
+	c.JSON(http.StatusOK, competency)
+}
+
+func UpdateCompetency(c *gin.Context) {
+	id := c.Param("id")
+	var competency Competency
+	if err := DB.First(&competency, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Competency not found"})
+		return
+	}
+
+	var input Competency
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	competency.Name = input.Name
+	competency.Description = input.Description
+	competency.Levels = input.Levels
+	if msg := validateCompetencyLevels(&competency); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	low, high := competencyLevelRange(competency)
+	var outOfRange int64
+	if err := DB.Model(&CompetencyRating{}).Where("competency_id = ? AND (rating < ? OR rating > ?)", competency.ID, low, high).Count(&outOfRange).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if outOfRange > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Existing ratings fall outside levels " + strconv.Itoa(low) + " to " + strconv.Itoa(high)})
+		return
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("competency_id = ?", competency.ID).Delete(&CompetencyLevel{}).Error; err != nil {
+			return err
+		}
+		return tx.Save(&competency).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, competency)
+}
+
+func DeleteCompetency(c *gin.Context) {
+	id := c.Param("id")
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&CompetencyLevel{}, &CompetencyRating{}, &CompetencyExpectation{}} {
+			if err := tx.Where("competency_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&Competency{}, id).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Competency deleted"})
+}
+
+func SetCompetencyExpectations(c *gin.Context) {
+	id := c.Param("id")
+	var member TeamMember
+	if err := DB.First(&member, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	var expectations []CompetencyExpectation
+	if err := c.ShouldBindJSON(&expectations); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ratings := make([]CompetencyRating, len(expectations))
+	for i, expectation := range expectations {
+		ratings[i] = CompetencyRating{CompetencyID: expectation.CompetencyID, Rating: expectation.ExpectedLevel}
+	}
+	if msg := validateCompetencyRatings(ratings, member.ID); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	for i := range expectations {
+		expectations[i].ID = 0
+		expectations[i].MemberID = member.ID
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("member_id = ?", member.ID).Delete(&CompetencyExpectation{}).Error; err != nil {
+			return err
+		}
+		if len(expectations) == 0 {
+			return nil
+		}
+		return tx.Create(&expectations).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, expectations)
+}
+
+func GetMemberCompetencyProfile(c *gin.Context) {
+	id := c.Param("id")
+	var member TeamMember
+	if err := DB.First(&member, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	var competencies []Competency
+	if err := DB.Order("name").Find(&competencies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var expectations []CompetencyExpectation
+	if err := DB.Where("member_id = ?", member.ID).Find(&expectations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var ratings []CompetencyRating
+	if err := DB.Where("member_id = ?", member.ID).
+		Where("feedback_id IS NULL OR feedback_id IN (?)", DB.Model(&Feedback{}).Scopes(revealedFeedback, publishedFeedback, activeFeedback).Select("id")).
+		Where("review_id IS NULL OR review_id IN (?)", DB.Model(&Review{}).Select("id").Where("status = ?", "submitted")).
+		Order("created_at, id").
+		Find(&ratings).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries := map[uint]*CompetencyProfileEntry{}
+	totals := map[uint]int{}
+	for _, competency := range competencies {
+		entries[competency.ID] = &CompetencyProfileEntry{CompetencyID: competency.ID, Name: competency.Name}
+	}
+	for _, expectation := range expectations {
+		if entry, ok := entries[expectation.CompetencyID]; ok {
+			expected := expectation.ExpectedLevel
+			entry.ExpectedLevel = &expected
+		}
+	}
+	for _, rating := range ratings {
+		if entry, ok := entries[rating.CompetencyID]; ok {
+			latest := rating.Rating
+			entry.LatestRating = &latest
+			entry.Ratings++
+			totals[rating.CompetencyID] += rating.Rating
+		}
+	}
+
+	profile := []CompetencyProfileEntry{}
+	gaps := []CompetencyProfileEntry{}
+	for _, competency := range competencies {
+		entry := entries[competency.ID]
+		if entry.Ratings > 0 {
+			average := math.Round(float64(totals[competency.ID])/float64(entry.Ratings)*100) / 100
+			entry.AverageRating = &average
+		}
+		if entry.ExpectedLevel != nil && entry.AverageRating != nil {
+			gap := math.Round((float64(*entry.ExpectedLevel)-*entry.AverageRating)*100) / 100
+			entry.Gap = &gap
+		}
+		if entry.ExpectedLevel == nil && entry.Ratings == 0 {
+			continue
+		}
+		profile = append(profile, *entry)
+		if entry.Gap != nil && *entry.Gap > 0 {
+			gaps = append(gaps, *entry)
+		}
+	}
+	sort.SliceStable(gaps, func(i, j int) bool { return *gaps[i].Gap > *gaps[j].Gap })
+
+	c.JSON(http.StatusOK, gin.H{"member_id": member.ID, "competencies": profile, "gaps": gaps})
+}
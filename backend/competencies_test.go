@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompetencyFramework(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	t.Run("Create competency with levels", func(t *testing.T) {
+		body := `{"name": "Communication", "levels": [
+			{"level": 1, "name": "Learning"},
+			{"level": 2, "name": "Practicing"},
+			{"level": 3, "name": "Leading"}]}`
+		req, _ := http.NewRequest("POST", "/api/v1/competencies", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var response Competency
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response.Levels, 3)
+	})
+
+	t.Run("Reject duplicate levels", func(t *testing.T) {
+		body := `{"name": "Delivery", "levels": [{"level": 1, "name": "A"}, {"level": 1, "name": "B"}]}`
+		req, _ := http.NewRequest("POST", "/api/v1/competencies", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestCompetencyRatingsAndProfile(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	member := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	team := Team{Name: "Development Team"}
+	DB.Create(&member)
+	DB.Create(&team)
+	communication := Competency{Name: "Communication", Levels: []CompetencyLevel{{Level: 1, Name: "Learning"}, {Level: 2, Name: "Practicing"}, {Level: 3, Name: "Leading"}}}
+	testingSkill := Competency{Name: "Testing"}
+	DB.Create(&communication)
+	DB.Create(&testingSkill)
+	memberID := strconv.Itoa(int(member.ID))
+	communicationID := strconv.Itoa(int(communication.ID))
+	testingID := strconv.Itoa(int(testingSkill.ID))
+
+	submit := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/api/v1/feedback", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Tag feedback with competency ratings", func(t *testing.T) {
+		w := submit(`{"content": "Clear design doc", "target_type": "member", "target_id": ` + memberID + `,
+			"competencies": [{"competency_id": ` + communicationID + `, "rating": 1}, {"competency_id": ` + testingID + `, "rating": 4}]}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var response Feedback
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response.Competencies, 2)
+		assert.Equal(t, member.ID, response.Competencies[0].MemberID)
+
+		submit(`{"content": "Great demo", "target_type": "member", "target_id": ` + memberID + `,
+			"competencies": [{"competency_id": ` + communicationID + `, "rating": 2}]}`)
+	})
+
+	t.Run("Reject rating outside the level range", func(t *testing.T) {
+		w := submit(`{"content": "Meh", "target_type": "member", "target_id": ` + memberID + `,
+			"competencies": [{"competency_id": ` + communicationID + `, "rating": 4}]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Reject ratings on team feedback", func(t *testing.T) {
+		w := submit(`{"content": "Nice", "target_type": "team", "target_id": ` + strconv.Itoa(int(team.ID)) + `,
+			"competencies": [{"competency_id": ` + communicationID + `, "rating": 2}]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Levels cannot shrink below existing ratings", func(t *testing.T) {
+		w := performRequest(router, "PUT", "/api/v1/competencies/"+communicationID, `{"name": "Communication", "levels": [{"level": 1, "name": "Learning"}]}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = performRequest(router, "PUT", "/api/v1/competencies/"+communicationID, `{"name": "Communication", "levels": [{"level": 1, "name": "Learning"}, {"level": 2, "name": "Practicing"}, {"level": 3, "name": "Leading"}]}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Profile shows gaps versus expected level", func(t *testing.T) {
+		w := submit(`{"content": "Old news", "target_type": "member", "target_id": ` + memberID + `,
+			"competencies": [{"competency_id": ` + communicationID + `, "rating": 3}]}`)
+		var archived Feedback
+		json.Unmarshal(w.Body.Bytes(), &archived)
+		DB.Model(&archived).Update("archived_at", time.Now())
+
+		body := `[{"competency_id": ` + communicationID + `, "expected_level": 3}, {"competency_id": ` + testingID + `, "expected_level": 3}]`
+		req, _ := http.NewRequest("PUT", "/api/v1/members/"+memberID+"/competency-expectations", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ = http.NewRequest("GET", "/api/v1/members/"+memberID+"/competencies", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Competencies []CompetencyProfileEntry `json:"competencies"`
+			Gaps         []CompetencyProfileEntry `json:"gaps"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response.Competencies, 2)
+		assert.Equal(t, 1.5, *response.Competencies[0].AverageRating)
+		assert.Equal(t, 2, *response.Competencies[0].LatestRating)
+		assert.Equal(t, 1.5, *response.Competencies[0].Gap)
+		assert.Equal(t, -1.0, *response.Competencies[1].Gap)
+		assert.Len(t, response.Gaps, 1)
+		assert.Equal(t, "Communication", response.Gaps[0].Name)
+	})
+}
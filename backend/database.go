@@ -16,13 +16,35 @@ func InitDatabase() {
 	}
 
 	var err error
-	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
-	err = DB.AutoMigrate(&TeamMember{}, &Team{}, &Feedback{})
+	if err = setupJoinTables(DB); err != nil {
+		log.Fatal("Failed to set up join tables:", err)
+	}
+
+	err = DB.AutoMigrate(
+		&TeamMember{}, &Team{}, &Feedback{},
+		&Goal{}, &KeyResult{}, &GoalCheckIn{},
+		&ActionItem{},
+		&ReviewCycle{}, &Review{},
+		&FeedbackRequest{}, &FeedbackRequestRecipient{}, &Notification{},
+		&SurveyTemplate{}, &SurveyQuestion{}, &SurveyRun{}, &SurveyResponse{}, &SurveyAnswer{},
+		&Retrospective{}, &RetroColumn{}, &RetroCard{}, &RetroVote{},
+		&Competency{}, &CompetencyLevel{}, &CompetencyRating{}, &CompetencyExpectation{},
+		&Skill{}, &MemberSkill{},
+		&FeedbackTemplate{}, &FeedbackReply{},
+		&ApprovalPolicy{}, &FeedbackApproval{}, &FeedbackApprovalEvent{},
+		&Project{}, &MembershipPeriod{},
+		&CustomFieldDefinition{}, &CustomFieldValue{},
+	)
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
+
+	if err = backfillMembershipPeriods(DB); err != nil {
+		log.Fatal("Failed to backfill membership periods:", err)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var validFeedbackStatuses = map[string]bool{
+	"draft":     true,
+	"scheduled": true,
+	"published": true,
+}
+
+var errFeedbackStatusChanged = errors.New("Feedback status changed while it was being released")
+
+func publishedFeedback(db *gorm.DB) *gorm.DB {
+	return db.Where("feedbacks.status = ?", "published")
+}
+
+func visibleFeedback(viewerID *uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == nil {
+			return publishedFeedback(db)
+		}
+		return db.Where("feedbacks.status = ? OR feedbacks.author_id = ?", "published", *viewerID)
+	}
+}
+
+func viewerFromQuery(c *gin.Context) *uint {
+	id, err := strconv.Atoi(c.Query("viewer_id"))
+	if err != nil || id <= 0 {
+		return nil
+	}
+	viewerID := uint(id)
+	return &viewerID
+}
+
+func validateFeedbackDelivery(feedback *Feedback, now time.Time) string {
+	if feedback.Status == "" {
+		feedback.Status = "published"
+	}
+	if !validFeedbackStatuses[feedback.Status] {
+		return "Status must be 'draft', 'scheduled' or 'published'"
+	}
+	if feedback.Status == "published" {
+		feedback.ReleaseAt = nil
+		return ""
+	}
+	if feedback.AuthorID == nil {
+		return "Draft and scheduled feedback require an author"
+	}
+	if feedback.Anonymous {
+		return "Anonymous feedback cannot be saved as a draft or scheduled"
+	}
+	if feedback.Status == "scheduled" && (feedback.ReleaseAt == nil || !feedback.ReleaseAt.After(now)) {
+		return "Scheduled feedback requires a release_at in the future"
+	}
+	if feedback.Status == "draft" {
+		feedback.ReleaseAt = nil
+	}
+	return ""
+}
+
+func notifyFeedbackReleased(tx *gorm.DB, feedback *Feedback) error {
+	if feedback.TargetType != "member" {
+		return nil
+	}
+	return notify(tx, feedback.TargetID, "feedback_released", "You received new feedback")
+}
+
+func releaseFeedback(tx *gorm.DB, feedback *Feedback, from string, now time.Time) (bool, error) {
+	result := tx.Model(&Feedback{ID: feedback.ID}).Where("status = ?", from).Updates(map[string]interface{}{
+		"status":     "published",
+		"release_at": now,
+	})
+	if result.Error != nil || result.RowsAffected != 1 {
+		return false, result.Error
+	}
+	feedback.Status = "published"
+	feedback.ReleaseAt = &now
+	return true, notifyFeedbackReleased(tx, feedback)
+}
+
+func releaseScheduledFeedback(db *gorm.DB, now time.Time) (int, error) {
+	var due []Feedback
+	if err := db.Where("status = ? AND release_at <= ?", "scheduled", now).Find(&due).Error; err != nil {
+		return 0, err
+	}
+
+	released := 0
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for i := range due {
+			ok, err := releaseFeedback(tx, &due[i], "scheduled", *due[i].ReleaseAt)
+			if err != nil {
+				return err
+			}
+			if ok {
+				released++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return released, nil
+}
+
+func PublishFeedback(c *gin.Context) {
+	var feedback Feedback
+	if err := DB.First(&feedback, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback not found"})
+		return
+	}
+
+	var request FeedbackPublishRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if feedback.AuthorID == nil || *feedback.AuthorID != request.AuthorID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the author can publish feedback"})
+		return
+	}
+
+	if feedback.Status != "draft" && feedback.Status != "scheduled" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only draft or scheduled feedback can be published"})
+		return
+	}
+
+	now := time.Now()
+	from := feedback.Status
+	feedback.Status = "published"
+	if request.ReleaseAt != nil {
+		if !request.ReleaseAt.After(now) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "release_at must be in the future"})
+			return
+		}
+		feedback.Status = "scheduled"
+		feedback.ReleaseAt = request.ReleaseAt
+	}
+
+	approval, msg := prepareFeedbackApproval(&feedback)
+	if msg != "" {
+		c.JSON(http.StatusConflict, gin.H{"error": msg})
+		return
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if approval == nil && feedback.Status == "published" {
+			released, err := releaseFeedback(tx, &feedback, from, now)
+			if err == nil && !released {
+				err = errFeedbackStatusChanged
+			}
+			return err
+		}
+		if err := tx.Model(&Feedback{ID: feedback.ID}).Updates(map[string]interface{}{
+			"status":     feedback.Status,
+			"release_at": feedback.ReleaseAt,
+		}).Error; err != nil {
+			return err
+		}
+		if approval == nil {
+			return nil
+		}
+		return createFeedbackApproval(tx, &feedback, approval)
+	})
+	if errors.Is(err, errFeedbackStatusChanged) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, feedback)
+}
+
+func GetMemberDrafts(c *gin.Context) {
+	id := c.Param("id")
+	var member TeamMember
+	if err := DB.First(&member, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	var feedbacks []Feedback
+	if err := DB.Preload("Competencies").
+		Where("author_id = ? AND status IN ?", member.ID, []string{"draft", "scheduled"}).
+		Order("updated_at desc, id desc").
+		Find(&feedbacks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, feedbacks)
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDraftFeedback(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	coach := TeamMember{Name: "Coach", Email: "coach@example.com"}
+	alice := TeamMember{Name: "Alice", Email: "alice@example.com"}
+	DB.Create(&coach)
+	DB.Create(&alice)
+
+	target := `"target_type": "member", "target_id": ` + strconv.Itoa(int(alice.ID))
+	coachID := strconv.Itoa(int(coach.ID))
+
+	post := func(url, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", url, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	list := func(url string) []Feedback {
+		req, _ := http.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var feedbacks []Feedback
+		json.Unmarshal(w.Body.Bytes(), &feedbacks)
+		return feedbacks
+	}
+
+	var draft Feedback
+
+	t.Run("Draft requires an author", func(t *testing.T) {
+		w := post("/api/v1/feedback", `{`+target+`, "content": "Notes for 1:1", "status": "draft"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Draft is visible only to the author", func(t *testing.T) {
+		w := post("/api/v1/feedback", `{`+target+`, "content": "Notes for 1:1", "status": "draft", "author_id": `+coachID+`}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &draft)
+
+		assert.Len(t, list("/api/v1/feedback"), 0)
+		assert.Len(t, list("/api/v1/feedback?viewer_id="+strconv.Itoa(int(alice.ID))), 0)
+		assert.Len(t, list("/api/v1/feedback?viewer_id="+coachID), 1)
+		assert.Len(t, list("/api/v1/members/"+coachID+"/drafts"), 1)
+
+		req, _ := http.NewRequest("GET", "/api/v1/feedback/"+strconv.Itoa(int(draft.ID)), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Only the author publishes", func(t *testing.T) {
+		w := post("/api/v1/feedback/"+strconv.Itoa(int(draft.ID))+"/publish", `{"author_id": `+strconv.Itoa(int(alice.ID))+`}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Publishing makes it visible and notifies the target", func(t *testing.T) {
+		w := post("/api/v1/feedback/"+strconv.Itoa(int(draft.ID))+"/publish", `{"author_id": `+coachID+`}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		assert.Len(t, list("/api/v1/feedback"), 1)
+
+		var notifications []Notification
+		DB.Where("member_id = ? AND kind = ?", alice.ID, "feedback_released").Find(&notifications)
+		assert.Len(t, notifications, 1)
+
+		w = post("/api/v1/feedback/"+strconv.Itoa(int(draft.ID))+"/publish", `{"author_id": `+coachID+`}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestScheduledFeedback(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	coach := TeamMember{Name: "Coach", Email: "coach@example.com"}
+	alice := TeamMember{Name: "Alice", Email: "alice@example.com"}
+	DB.Create(&coach)
+	DB.Create(&alice)
+
+	target := `"target_type": "member", "target_id": ` + strconv.Itoa(int(alice.ID))
+	releaseAt := time.Now().Add(2 * time.Hour).UTC()
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/api/v1/feedback", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Scheduled feedback needs a future release time", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		w := post(`{` + target + `, "content": "Later", "status": "scheduled", "author_id": ` + strconv.Itoa(int(coach.ID)) + `, "release_at": "` + past + `"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Scheduler releases due feedback", func(t *testing.T) {
+		w := post(`{` + target + `, "content": "Later", "status": "scheduled", "author_id": ` + strconv.Itoa(int(coach.ID)) + `, "release_at": "` + releaseAt.Format(time.RFC3339) + `"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var scheduled Feedback
+		json.Unmarshal(w.Body.Bytes(), &scheduled)
+
+		released, err := releaseScheduledFeedback(DB, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, released)
+
+		released, err = releaseScheduledFeedback(DB, releaseAt.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, released)
+
+		var stored Feedback
+		DB.First(&stored, scheduled.ID)
+		assert.Equal(t, "published", stored.Status)
+
+		var notifications []Notification
+		DB.Where("member_id = ? AND kind = ?", alice.ID, "feedback_released").Find(&notifications)
+		assert.Len(t, notifications, 1)
+	})
+
+	t.Run("Feedback released elsewhere is not released twice", func(t *testing.T) {
+		stale := Feedback{Content: "Race", TargetType: "member", TargetID: alice.ID, AuthorID: &coach.ID, Status: "scheduled", ReleaseAt: &releaseAt}
+		DB.Create(&stale)
+		DB.Model(&Feedback{ID: stale.ID}).Update("status", "published")
+
+		released, err := releaseFeedback(DB, &stale, "scheduled", releaseAt)
+		assert.NoError(t, err)
+		assert.False(t, released)
+
+		var count int64
+		DB.Model(&Notification{}).Where("member_id = ? AND kind = ?", alice.ID, "feedback_released").Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+}
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const feedbackRequestReminderInterval = 24 * time.Hour
+
+func computeFeedbackRequestCompletion(request *FeedbackRequest) {
+	completion := FeedbackRequestCompletion{Total: len(request.Recipients)}
+	for _, recipient := range request.Recipients {
+		switch recipient.Status {
+		case "completed":
+			completion.Completed++
+		case "declined":
+			completion.Declined++
+		default:
+			completion.Pending++
+		}
+	}
+	if completion.Total > 0 {
+		completion.Percent = (completion.Completed + completion.Declined) * 100 / completion.Total
+	}
+	request.Completion = &completion
+}
+
+func loadFeedbackRequest(id string) (*FeedbackRequest, error) {
+	var request FeedbackRequest
+	if err := DB.Preload("Recipients").First(&request, id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func findPendingRecipient(request *FeedbackRequest, recipientID uint) (*FeedbackRequestRecipient, string) {
+	if request.Status != "open" {
+		return nil, "Feedback request is not open"
+	}
+	for i := range request.Recipients {
+		if request.Recipients[i].RecipientID == recipientID {
+			if request.Recipients[i].Status != "pending" {
+				return nil, "Feedback request has already been " + request.Recipients[i].Status + " by this recipient"
+			}
+			return &request.Recipients[i], ""
+		}
+	}
+	return nil, ""
+}
+
+func closeFeedbackRequestIfDone(tx *gorm.DB, request *FeedbackRequest) error {
+	for _, recipient := range request.Recipients {
+		if recipient.Status == "pending" {
+			return nil
+		}
+	}
+	request.Status = "completed"
+	return tx.Model(request).Update("status", request.Status).Error
+}
+
+func CreateFeedbackRequest(c *gin.Context) {
+	var input FeedbackRequestCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var requester, target TeamMember
+	if err := DB.First(&requester, input.RequesterID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Requester not found"})
+		return
+	}
+	if err := DB.First(&target, input.TargetMemberID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Target member not found"})
+		return
+	}
+
+	request := FeedbackRequest{
+		RequesterID:    &requester.ID,
+		TargetMemberID: target.ID,
+		Message:        input.Message,
+		DueDate:        input.DueDate,
+		Status:         "open",
+	}
+
+	seen := map[uint]bool{}
+	for _, recipientID := range input.RecipientIDs {
+		if seen[recipientID] {
+			continue
+		}
+		if recipientID == target.ID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "The target member cannot be asked for feedback about themselves"})
+			return
+		}
+		seen[recipientID] = true
+		request.Recipients = append(request.Recipients, FeedbackRequestRecipient{RecipientID: recipientID, Status: "pending"})
+	}
+
+	if len(request.Recipients) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one recipient is required"})
+		return
+	}
+
+	var count int64
+	DB.Model(&TeamMember{}).Where("id IN ?", input.RecipientIDs).Count(&count)
+	if int(count) != len(request.Recipients) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Recipient not found"})
+		return
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&request).Error; err != nil {
+			return err
+		}
+		for _, recipient := range request.Recipients {
+			if err := notify(tx, recipient.RecipientID, "feedback_request",
+				requester.Name+" asked for your feedback about "+target.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	computeFeedbackRequestCompletion(&request)
+	c.JSON(http.StatusCreated, request)
+}
+
+func GetFeedbackRequests(c *gin.Context) {
+	query := DB.Preload("Recipients")
+
+	if requesterID := c.Query("requester_id"); requesterID != "" {
+		if id, err := strconv.Atoi(requesterID); err == nil {
+			query = query.Where("requester_id = ?", id)
+		}
+	}
+
+	if targetID := c.Query("target_member_id"); targetID != "" {
+		if id, err := strconv.Atoi(targetID); err == nil {
+			query = query.Where("target_member_id = ?", id)
+		}
+	}
+
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var requests []FeedbackRequest
+	if err := query.Order("created_at desc, id desc").Find(&requests).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for i := range requests {
+		computeFeedbackRequestCompletion(&requests[i])
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+func GetFeedbackRequest(c *gin.Context) {
+	request, err := loadFeedbackRequest(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback request not found"})
+		return
+	}
+
+	computeFeedbackRequestCompletion(request)
+	c.JSON(http.StatusOK, request)
+}
+
+func GetPendingFeedbackRequests(c *gin.Context) {
+	id := c.Param("id")
+	var member TeamMember
+	if err := DB.First(&member, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	var requests []FeedbackRequest
+	if err := DB.Preload("Recipients").
+		Where("status = ?", "open").
+		Where("id IN (?)", DB.Model(&FeedbackRequestRecipient{}).
+			Select("feedback_request_id").
+			Where("recipient_id = ? AND status = ?", member.ID, "pending")).
+		Order("due_date IS NULL, due_date, id").
+		Find(&requests).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for i := range requests {
+		computeFeedbackRequestCompletion(&requests[i])
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+func RespondToFeedbackRequest(c *gin.Context) {
+	request, err := loadFeedbackRequest(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback request not found"})
+		return
+	}
+
+	var response FeedbackRequestResponse
+	if err := c.ShouldBindJSON(&response); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if response.Content == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Content is required"})
+		return
+	}
+
+	recipient, msg := findPendingRecipient(request, response.RecipientID)
+	if msg != "" {
+		c.JSON(http.StatusConflict, gin.H{"error": msg})
+		return
+	}
+	if recipient == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Member was not asked for feedback in this request"})
+		return
+	}
+
+	authorID := recipient.RecipientID
+	feedback := Feedback{
+		Content:    response.Content,
+		TargetType: "member",
+		TargetID:   request.TargetMemberID,
+		AuthorID:   &authorID,
+	}
+	approval, code, msg := prepareNewFeedback(&feedback)
+	if msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+	feedback.FeedbackRequestID = &request.ID
+
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&feedback).Error; err != nil {
+			return err
+		}
+		if approval != nil {
+			if err := createFeedbackApproval(tx, &feedback, approval); err != nil {
+				return err
+			}
+		}
+		now := time.Now()
+		recipient.Status = "completed"
+		recipient.FeedbackID = &feedback.ID
+		recipient.RespondedAt = &now
+		if err := tx.Save(recipient).Error; err != nil {
+			return err
+		}
+		if err := closeFeedbackRequestIfDone(tx, request); err != nil {
+			return err
+		}
+		if request.RequesterID == nil {
+			return nil
+		}
+		return notify(tx, *request.RequesterID, "feedback_request_response", "A feedback request you sent received a response")
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, feedback)
+}
+
+func DeclineFeedbackRequest(c *gin.Context) {
+	request, err := loadFeedbackRequest(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback request not found"})
+		return
+	}
+
+	var decline FeedbackRequestDecline
+	if err := c.ShouldBindJSON(&decline); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if decline.Reason == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Reason is required"})
+		return
+	}
+
+	recipient, msg := findPendingRecipient(request, decline.RecipientID)
+	if msg != "" {
+		c.JSON(http.StatusConflict, gin.H{"error": msg})
+		return
+	}
+	if recipient == nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Member was not asked for feedback in this request"})
+		return
+	}
+
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		recipient.Status = "declined"
+		recipient.DeclineReason = decline.Reason
+		recipient.RespondedAt = &now
+		if err := tx.Save(recipient).Error; err != nil {
+			return err
+		}
+		if err := closeFeedbackRequestIfDone(tx, request); err != nil {
+			return err
+		}
+		if request.RequesterID == nil {
+			return nil
+		}
+		return notify(tx, *request.RequesterID, "feedback_request_declined", "A feedback request you sent was declined: "+decline.Reason)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	computeFeedbackRequestCompletion(request)
+	c.JSON(http.StatusOK, request)
+}
+
+func RemindFeedbackRequest(c *gin.Context) {
+	request, err := loadFeedbackRequest(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback request not found"})
+		return
+	}
+
+	if request.Status != "open" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Feedback request is not open"})
+		return
+	}
+
+	var target TeamMember
+	if err := DB.First(&target, request.TargetMemberID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Target member not found"})
+		return
+	}
+
+	now := time.Now()
+	reminded := []uint{}
+	skipped := []uint{}
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		for i := range request.Recipients {
+			recipient := &request.Recipients[i]
+			if recipient.Status != "pending" {
+				continue
+			}
+			if recipient.LastRemindedAt != nil && now.Sub(*recipient.LastRemindedAt) < feedbackRequestReminderInterval {
+				skipped = append(skipped, recipient.RecipientID)
+				continue
+			}
+			recipient.ReminderCount++
+			recipient.LastRemindedAt = &now
+			if err := tx.Save(recipient).Error; err != nil {
+				return err
+			}
+			if err := notify(tx, recipient.RecipientID, "feedback_request_reminder",
+				"Reminder: your feedback about "+target.Name+" is still pending"); err != nil {
+				return err
+			}
+			reminded = append(reminded, recipient.RecipientID)
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reminded": reminded, "skipped": skipped})
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedbackRequestWorkflow(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	coach := TeamMember{Name: "Coach Carter", Email: "coach@example.com"}
+	target := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	peer1 := TeamMember{Name: "Jane Smith", Email: "jane@example.com"}
+	peer2 := TeamMember{Name: "Bob Johnson", Email: "bob@example.com"}
+	DB.Create(&coach)
+	DB.Create(&target)
+	DB.Create(&peer1)
+	DB.Create(&peer2)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	var request FeedbackRequest
+
+	t.Run("Create feedback request", func(t *testing.T) {
+		input := FeedbackRequestCreate{
+			RequesterID:    coach.ID,
+			TargetMemberID: target.ID,
+			RecipientIDs:   []uint{peer1.ID, peer2.ID},
+			Message:        "How did John do on the migration?",
+		}
+		jsonValue, _ := json.Marshal(input)
+		w := post("/api/v1/feedback-requests", string(jsonValue))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &request)
+		assert.Len(t, request.Recipients, 2)
+		assert.Equal(t, 2, request.Completion.Pending)
+
+		var notifications []Notification
+		json.Unmarshal(get("/api/v1/members/"+strconv.Itoa(int(peer1.ID))+"/notifications").Body.Bytes(), &notifications)
+		assert.Len(t, notifications, 1)
+		assert.Equal(t, "feedback_request", notifications[0].Kind)
+	})
+
+	requestPath := "/api/v1/feedback-requests/" + strconv.Itoa(int(request.ID))
+
+	t.Run("Recipients see pending requests", func(t *testing.T) {
+		var pending []FeedbackRequest
+		json.Unmarshal(get("/api/v1/members/"+strconv.Itoa(int(peer1.ID))+"/feedback-requests").Body.Bytes(), &pending)
+		assert.Len(t, pending, 1)
+	})
+
+	t.Run("Cannot request feedback about the target from the target", func(t *testing.T) {
+		body := `{"requester_id": ` + strconv.Itoa(int(coach.ID)) + `, "target_member_id": ` + strconv.Itoa(int(target.ID)) + `, "recipient_ids": [` + strconv.Itoa(int(target.ID)) + `]}`
+		assert.Equal(t, http.StatusBadRequest, post("/api/v1/feedback-requests", body).Code)
+	})
+
+	t.Run("Respond with feedback", func(t *testing.T) {
+		w := post(requestPath+"/respond", `{"recipient_id": `+strconv.Itoa(int(peer1.ID))+`, "content": "Great ownership of the migration"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var feedback Feedback
+		json.Unmarshal(w.Body.Bytes(), &feedback)
+		assert.Equal(t, "member", feedback.TargetType)
+		assert.Equal(t, target.ID, feedback.TargetID)
+		assert.Equal(t, request.ID, *feedback.FeedbackRequestID)
+		assert.Equal(t, peer1.ID, *feedback.AuthorID)
+
+		assert.Equal(t, http.StatusConflict, post(requestPath+"/respond", `{"recipient_id": `+strconv.Itoa(int(peer1.ID))+`, "content": "Again"}`).Code)
+		assert.Equal(t, http.StatusForbidden, post(requestPath+"/respond", `{"recipient_id": `+strconv.Itoa(int(coach.ID))+`, "content": "Me too"}`).Code)
+	})
+
+	t.Run("Remind pending recipients once per interval", func(t *testing.T) {
+		var response map[string][]uint
+		json.Unmarshal(post(requestPath+"/remind", `{}`).Body.Bytes(), &response)
+		assert.Equal(t, []uint{peer2.ID}, response["reminded"])
+
+		json.Unmarshal(post(requestPath+"/remind", `{}`).Body.Bytes(), &response)
+		assert.Empty(t, response["reminded"])
+		assert.Equal(t, []uint{peer2.ID}, response["skipped"])
+	})
+
+	t.Run("Decline requires a reason and completes the request", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, post(requestPath+"/decline", `{"recipient_id": `+strconv.Itoa(int(peer2.ID))+`}`).Code)
+
+		w := post(requestPath+"/decline", `{"recipient_id": `+strconv.Itoa(int(peer2.ID))+`, "reason": "Did not work with John"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var updated FeedbackRequest
+		json.Unmarshal(get(requestPath).Body.Bytes(), &updated)
+		assert.Equal(t, "completed", updated.Status)
+		assert.Equal(t, 1, updated.Completion.Completed)
+		assert.Equal(t, 1, updated.Completion.Declined)
+		assert.Equal(t, 100, updated.Completion.Percent)
+	})
+}
+
+func TestFeedbackRequestResponsesFollowApprovalPolicy(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	coach := TeamMember{Name: "Coach Carter", Email: "coach@example.com"}
+	target := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	peer := TeamMember{Name: "Jane Smith", Email: "jane@example.com"}
+	DB.Create(&coach)
+	DB.Create(&target)
+	DB.Create(&peer)
+	DB.Create(&ApprovalPolicy{Kind: "observation", ApproverID: &coach.ID})
+
+	request := FeedbackRequest{RequesterID: &coach.ID, TargetMemberID: target.ID, Recipients: []FeedbackRequestRecipient{{RecipientID: peer.ID}}}
+	DB.Create(&request)
+
+	body := `{"recipient_id": ` + strconv.Itoa(int(peer.ID)) + `, "content": "Rushed the rollout"}`
+	req, _ := http.NewRequest("POST", "/api/v1/feedback-requests/"+strconv.Itoa(int(request.ID))+"/respond", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var feedback Feedback
+	json.Unmarshal(w.Body.Bytes(), &feedback)
+	assert.Equal(t, "pending_approval", feedback.Status)
+	assert.Equal(t, request.ID, *feedback.FeedbackRequestID)
+
+	var approval FeedbackApproval
+	assert.NoError(t, DB.Where("feedback_id = ?", feedback.ID).First(&approval).Error)
+	assert.Equal(t, []uint{coach.ID}, approval.Approvers)
+}
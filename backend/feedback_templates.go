@@ -0,0 +1,221 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultFeedbackKind = "observation"
+
+var validFeedbackKinds = map[string]bool{
+	"kudos":        true,
+	"constructive": true,
+	"observation":  true,
+}
+
+var feedbackStructuredFields = map[string]func(*Feedback) string{
+	"content":   func(f *Feedback) string { return f.Content },
+	"situation": func(f *Feedback) string { return f.Situation },
+	"behavior":  func(f *Feedback) string { return f.Behavior },
+	"impact":    func(f *Feedback) string { return f.Impact },
+}
+
+func composeSBIContent(feedback *Feedback) string {
+	parts := []string{}
+	if feedback.Situation != "" {
+		parts = append(parts, "Situation: "+feedback.Situation)
+	}
+	if feedback.Behavior != "" {
+		parts = append(parts, "Behavior: "+feedback.Behavior)
+	}
+	if feedback.Impact != "" {
+		parts = append(parts, "Impact: "+feedback.Impact)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func validateFeedbackStructure(feedback *Feedback, previousTemplateID *uint) (int, string) {
+	feedback.Kind = strings.ToLower(strings.TrimSpace(feedback.Kind))
+	feedback.Situation = strings.TrimSpace(feedback.Situation)
+	feedback.Behavior = strings.TrimSpace(feedback.Behavior)
+	feedback.Impact = strings.TrimSpace(feedback.Impact)
+
+	if feedback.TemplateID != nil {
+		var template FeedbackTemplate
+		if err := DB.First(&template, *feedback.TemplateID).Error; err != nil {
+			return http.StatusNotFound, "Feedback template not found"
+		}
+		if !template.Active && (previousTemplateID == nil || *previousTemplateID != template.ID) {
+			return http.StatusBadRequest, "Feedback template is not active"
+		}
+		if feedback.Kind == "" {
+			feedback.Kind = template.Kind
+		}
+		if feedback.Kind != template.Kind {
+			return http.StatusBadRequest, "Kind must be '" + template.Kind + "' for template " + template.Name
+		}
+		for _, field := range template.RequiredFields {
+			if field == "content" && feedback.Content == "" && composeSBIContent(feedback) != "" {
+				continue
+			}
+			if strings.TrimSpace(feedbackStructuredFields[field](feedback)) == "" {
+				return http.StatusBadRequest, "Template " + template.Name + " requires " + field
+			}
+		}
+	}
+
+	if feedback.Kind == "" {
+		feedback.Kind = defaultFeedbackKind
+	}
+	if !validFeedbackKinds[feedback.Kind] {
+		return http.StatusBadRequest, "Kind must be 'kudos', 'constructive' or 'observation'"
+	}
+
+	if strings.TrimSpace(feedback.Content) == "" {
+		feedback.Content = composeSBIContent(feedback)
+	}
+	return 0, ""
+}
+
+func validateFeedbackTemplate(template *FeedbackTemplate) string {
+	template.Name = strings.TrimSpace(template.Name)
+	template.Kind = strings.ToLower(strings.TrimSpace(template.Kind))
+	if template.Name == "" {
+		return "Name is required"
+	}
+	if !validFeedbackKinds[template.Kind] {
+		return "Kind must be 'kudos', 'constructive' or 'observation'"
+	}
+	seen := map[string]bool{}
+	fields := []string{}
+	for _, field := range template.RequiredFields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if _, ok := feedbackStructuredFields[field]; !ok {
+			return "Unknown required field " + field
+		}
+		if !seen[field] {
+			fields = append(fields, field)
+			seen[field] = true
+		}
+	}
+	template.RequiredFields = fields
+	return ""
+}
+
+func CreateFeedbackTemplate(c *gin.Context) {
+	var template FeedbackTemplate
+	if err := c.ShouldBindJSON(&template); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template.ID = 0
+	template.Active = true
+	if msg := validateFeedbackTemplate(&template); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	if err := DB.Create(&template).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+func GetFeedbackTemplates(c *gin.Context) {
+	query := DB.Order("name")
+	if kind := c.Query("kind"); kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	if c.Query("include_inactive") != "true" {
+		query = query.Where("active = ?", true)
+	}
+
+	var templates []FeedbackTemplate
+	if err := query.Find(&templates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, templates)
+}
+
+func GetFeedbackTemplate(c *gin.Context) {
+	id := c.Param("id")
+	var template FeedbackTemplate
+	if err := DB.First(&template, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback template not found"})
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+func UpdateFeedbackTemplate(c *gin.Context) {
+	id := c.Param("id")
+	var template FeedbackTemplate
+	if err := DB.First(&template, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback template not found"})
+		return
+	}
+
+	var input struct {
+		Name           string   `json:"name"`
+		Kind           string   `json:"kind"`
+		Description    string   `json:"description"`
+		RequiredFields []string `json:"required_fields"`
+		Active         *bool    `json:"active"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template.Name = input.Name
+	template.Kind = input.Kind
+	template.Description = input.Description
+	template.RequiredFields = input.RequiredFields
+	if input.Active != nil {
+		template.Active = *input.Active
+	}
+	if msg := validateFeedbackTemplate(&template); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	if err := DB.Save(&template).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+func DeleteFeedbackTemplate(c *gin.Context) {
+	id := c.Param("id")
+	var template FeedbackTemplate
+	if err := DB.First(&template, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback template not found"})
+		return
+	}
+
+	var count int64
+	DB.Model(&Feedback{}).Where("template_id = ?", template.ID).Count(&count)
+	if count > 0 {
+		template.Active = false
+		if err := DB.Save(&template).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Feedback template deactivated"})
+		return
+	}
+
+	if err := DB.Delete(&template).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Feedback template deleted"})
+}
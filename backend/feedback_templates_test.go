@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedbackTemplates(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	member := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	DB.Create(&member)
+
+	var template FeedbackTemplate
+	var composed Feedback
+
+	t.Run("Create SBI template", func(t *testing.T) {
+		body := `{"name": "Constructive SBI", "kind": "constructive", "required_fields": ["situation", "behavior", "impact"]}`
+		req, _ := http.NewRequest("POST", "/api/v1/feedback-templates", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &template)
+		assert.Equal(t, []string{"situation", "behavior", "impact"}, template.RequiredFields)
+	})
+
+	t.Run("Reject unknown required field", func(t *testing.T) {
+		body := `{"name": "Broken", "kind": "kudos", "required_fields": ["mood"]}`
+		req, _ := http.NewRequest("POST", "/api/v1/feedback-templates", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Feedback missing required field is rejected", func(t *testing.T) {
+		body := `{"target_type": "member", "target_id": ` + strconv.Itoa(int(member.ID)) + `, "template_id": ` + strconv.Itoa(int(template.ID)) + `,
+			"situation": "Sprint demo", "behavior": "Skipped the walkthrough"}`
+		req, _ := http.NewRequest("POST", "/api/v1/feedback", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Feedback content is composed from SBI fields", func(t *testing.T) {
+		body := `{"target_type": "member", "target_id": ` + strconv.Itoa(int(member.ID)) + `, "template_id": ` + strconv.Itoa(int(template.ID)) + `,
+			"situation": "Sprint demo", "behavior": "Skipped the walkthrough", "impact": "Stakeholders were confused"}`
+		req, _ := http.NewRequest("POST", "/api/v1/feedback", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		json.Unmarshal(w.Body.Bytes(), &composed)
+		assert.Equal(t, "constructive", composed.Kind)
+		assert.Contains(t, composed.Content, "Impact: Stakeholders were confused")
+	})
+
+	t.Run("Template kind must match", func(t *testing.T) {
+		body := `{"target_type": "member", "target_id": ` + strconv.Itoa(int(member.ID)) + `, "template_id": ` + strconv.Itoa(int(template.ID)) + `,
+			"kind": "kudos", "situation": "a", "behavior": "b", "impact": "c"}`
+		req, _ := http.NewRequest("POST", "/api/v1/feedback", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Free text feedback defaults to observation", func(t *testing.T) {
+		body := `{"target_type": "member", "target_id": ` + strconv.Itoa(int(member.ID)) + `, "content": "Nice work"}`
+		req, _ := http.NewRequest("POST", "/api/v1/feedback", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var response Feedback
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "observation", response.Kind)
+	})
+
+	t.Run("Filter feedback by kind", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/feedback?kind=constructive", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response []Feedback
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response, 1)
+	})
+
+	t.Run("Deleting a used template deactivates it", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", "/api/v1/feedback-templates/"+strconv.Itoa(int(template.ID)), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var stored FeedbackTemplate
+		assert.NoError(t, DB.First(&stored, template.ID).Error)
+		assert.False(t, stored.Active)
+	})
+
+	t.Run("Feedback using a deactivated template can still be edited", func(t *testing.T) {
+		body := `{"kind": "constructive", "situation": "Sprint demo", "behavior": "Skipped the walkthrough", "impact": "Stakeholders asked again"}`
+		req, _ := http.NewRequest("PUT", "/api/v1/feedback/"+strconv.Itoa(int(composed.ID)), bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		body = `{"target_type": "member", "target_id": ` + strconv.Itoa(int(member.ID)) + `, "template_id": ` + strconv.Itoa(int(template.ID)) + `,
+			"situation": "a", "behavior": "b", "impact": "c"}`
+		req, _ = http.NewRequest("POST", "/api/v1/feedback", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
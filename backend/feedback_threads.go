@@ -0,0 +1,199 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func canReplyToFeedback(feedback *Feedback, memberID uint) bool {
+	if feedback.AuthorID != nil && *feedback.AuthorID == memberID {
+		return true
+	}
+	return isFeedbackTarget(feedback, memberID)
+}
+
+func buildReplyThread(replies []FeedbackReply) []FeedbackReply {
+	children := map[uint][]FeedbackReply{}
+	roots := []FeedbackReply{}
+	for _, reply := range replies {
+		if reply.ParentID == nil {
+			roots = append(roots, reply)
+		} else {
+			children[*reply.ParentID] = append(children[*reply.ParentID], reply)
+		}
+	}
+
+	var attach func(reply FeedbackReply) FeedbackReply
+	attach = func(reply FeedbackReply) FeedbackReply {
+		reply.Replies = []FeedbackReply{}
+		for _, child := range children[reply.ID] {
+			reply.Replies = append(reply.Replies, attach(child))
+		}
+		return reply
+	}
+
+	thread := []FeedbackReply{}
+	for _, root := range roots {
+		thread = append(thread, attach(root))
+	}
+	return thread
+}
+
+func notifyFeedbackReply(tx *gorm.DB, feedback *Feedback, reply *FeedbackReply) error {
+	recipients := map[uint]bool{}
+	if feedback.AuthorID != nil {
+		recipients[*feedback.AuthorID] = true
+	}
+	if feedback.TargetType == "member" {
+		recipients[feedback.TargetID] = true
+	}
+	delete(recipients, reply.AuthorID)
+
+	for memberID := range recipients {
+		if err := notify(tx, memberID, "feedback_reply", "Someone replied to feedback you are part of"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func CreateFeedbackReply(c *gin.Context) {
+	var feedback Feedback
+	if err := DB.Scopes(revealedFeedback, publishedFeedback).First(&feedback, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback not found"})
+		return
+	}
+
+	var reply FeedbackReply
+	if err := c.ShouldBindJSON(&reply); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reply.Content = strings.TrimSpace(reply.Content)
+	if reply.Content == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Content is required"})
+		return
+	}
+
+	var author TeamMember
+	if err := DB.First(&author, reply.AuthorID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Author not found"})
+		return
+	}
+
+	if !canReplyToFeedback(&feedback, author.ID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the author or target of the feedback can reply"})
+		return
+	}
+
+	if reply.ParentID != nil {
+		var parent FeedbackReply
+		if err := DB.Where("feedback_id = ?", feedback.ID).First(&parent, *reply.ParentID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Parent reply not found"})
+			return
+		}
+	}
+
+	reply.ID = 0
+	reply.FeedbackID = feedback.ID
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&reply).Error; err != nil {
+			return err
+		}
+		return notifyFeedbackReply(tx, &feedback, &reply)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	reply.Replies = []FeedbackReply{}
+	c.JSON(http.StatusCreated, reply)
+}
+
+func GetFeedbackReplies(c *gin.Context) {
+	var feedback Feedback
+	if err := DB.Scopes(revealedFeedback, publishedFeedback).First(&feedback, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback not found"})
+		return
+	}
+
+	var replies []FeedbackReply
+	if err := DB.Where("feedback_id = ?", feedback.ID).Order("created_at, id").Find(&replies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildReplyThread(replies))
+}
+
+func AcknowledgeFeedback(c *gin.Context) {
+	var feedback Feedback
+	if err := DB.Scopes(revealedFeedback, publishedFeedback).First(&feedback, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback not found"})
+		return
+	}
+
+	var acknowledgement FeedbackAcknowledgement
+	if err := c.ShouldBindJSON(&acknowledgement); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !isFeedbackTarget(&feedback, acknowledgement.MemberID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the target of the feedback can acknowledge it"})
+		return
+	}
+
+	if feedback.AcknowledgedAt != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Feedback is already acknowledged"})
+		return
+	}
+
+	now := time.Now()
+	feedback.AcknowledgedAt = &now
+	feedback.AcknowledgedBy = &acknowledgement.MemberID
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Feedback{ID: feedback.ID}).UpdateColumns(map[string]interface{}{
+			"acknowledged_at": feedback.AcknowledgedAt,
+			"acknowledged_by": feedback.AcknowledgedBy,
+		}).Error; err != nil {
+			return err
+		}
+		if feedback.AuthorID == nil {
+			return nil
+		}
+		return notify(tx, *feedback.AuthorID, "feedback_acknowledged", "Feedback you gave was acknowledged")
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, feedback)
+}
+
+func GetUnacknowledgedFeedback(c *gin.Context) {
+	id := c.Param("id")
+	var member TeamMember
+	if err := DB.First(&member, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	var feedbacks []Feedback
+	if err := DB.Scopes(revealedFeedback, publishedFeedback).
+		Where("target_type = ? AND target_id = ? AND acknowledged_at IS NULL", "member", member.ID).
+		Order("created_at, id").
+		Find(&feedbacks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, feedbacks)
+}
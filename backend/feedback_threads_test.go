@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedbackReplies(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	alice := TeamMember{Name: "Alice", Email: "alice@example.com"}
+	bob := TeamMember{Name: "Bob", Email: "bob@example.com"}
+	carol := TeamMember{Name: "Carol", Email: "carol@example.com"}
+	DB.Create(&alice)
+	DB.Create(&bob)
+	DB.Create(&carol)
+	feedback := Feedback{Content: "Great demo", TargetType: "member", TargetID: alice.ID, AuthorID: &bob.ID}
+	DB.Create(&feedback)
+	url := "/api/v1/feedback/" + strconv.Itoa(int(feedback.ID)) + "/replies"
+
+	reply := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", url, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	var first FeedbackReply
+
+	t.Run("Target replies", func(t *testing.T) {
+		w := reply(`{"author_id": ` + strconv.Itoa(int(alice.ID)) + `, "content": "Thanks, which part helped most?"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &first)
+
+		var notifications []Notification
+		DB.Where("member_id = ? AND kind = ?", bob.ID, "feedback_reply").Find(&notifications)
+		assert.Len(t, notifications, 1)
+	})
+
+	t.Run("Author answers in thread", func(t *testing.T) {
+		w := reply(`{"author_id": ` + strconv.Itoa(int(bob.ID)) + `, "parent_id": ` + strconv.Itoa(int(first.ID)) + `, "content": "The live walkthrough"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Outsider cannot reply", func(t *testing.T) {
+		w := reply(`{"author_id": ` + strconv.Itoa(int(carol.ID)) + `, "content": "Agreed"}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Thread is nested", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var thread []FeedbackReply
+		json.Unmarshal(w.Body.Bytes(), &thread)
+		assert.Len(t, thread, 1)
+		assert.Len(t, thread[0].Replies, 1)
+		assert.Equal(t, "The live walkthrough", thread[0].Replies[0].Content)
+	})
+}
+
+func TestFeedbackAcknowledgement(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	alice := TeamMember{Name: "Alice", Email: "alice@example.com"}
+	bob := TeamMember{Name: "Bob", Email: "bob@example.com"}
+	DB.Create(&alice)
+	DB.Create(&bob)
+	first := Feedback{Content: "Great demo", TargetType: "member", TargetID: alice.ID, AuthorID: &bob.ID}
+	second := Feedback{Content: "Clear notes", TargetType: "member", TargetID: alice.ID}
+	DB.Create(&first)
+	DB.Create(&second)
+
+	acknowledge := func(feedbackID, memberID uint) int {
+		body := `{"member_id": ` + strconv.Itoa(int(memberID)) + `}`
+		req, _ := http.NewRequest("POST", "/api/v1/feedback/"+strconv.Itoa(int(feedbackID))+"/acknowledge", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("Only target can acknowledge", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, acknowledge(first.ID, bob.ID))
+	})
+
+	t.Run("Target acknowledges once", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, acknowledge(first.ID, alice.ID))
+		assert.Equal(t, http.StatusConflict, acknowledge(first.ID, alice.ID))
+
+		var stored Feedback
+		DB.First(&stored, first.ID)
+		assert.NotNil(t, stored.AcknowledgedAt)
+		assert.Equal(t, alice.ID, *stored.AcknowledgedBy)
+	})
+
+	t.Run("List unacknowledged feedback", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/members/"+strconv.Itoa(int(alice.ID))+"/unacknowledged-feedback", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response []Feedback
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response, 1)
+		assert.Equal(t, second.ID, response[0].ID)
+	})
+}
@@ -0,0 +1,452 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var goalStatuses = map[string]bool{
+	"not_started": true,
+	"on_track":    true,
+	"at_risk":     true,
+	"off_track":   true,
+	"completed":   true,
+}
+
+func ownerExists(ownerType string, ownerID uint) bool {
+	var count int64
+	switch ownerType {
+	case "member":
+		DB.Model(&TeamMember{}).Where("id = ?", ownerID).Count(&count)
+	case "team":
+		DB.Model(&Team{}).Where("id = ?", ownerID).Count(&count)
+	}
+	return count > 0
+}
+
+func validateGoal(goal *Goal) string {
+	if goal.Title == "" {
+		return "Title is required"
+	}
+	if goal.OwnerType != "team" && goal.OwnerType != "member" {
+		return "Owner type must be 'team' or 'member'"
+	}
+	if goal.Status == "" {
+		goal.Status = "not_started"
+	}
+	if !goalStatuses[goal.Status] {
+		return "Invalid goal status"
+	}
+	if goal.Progress < 0 || goal.Progress > 100 {
+		return "Progress must be between 0 and 100"
+	}
+	return ""
+}
+
+func validateKeyResult(keyResult *KeyResult) string {
+	if keyResult.Title == "" {
+		return "Title is required"
+	}
+	if keyResult.TargetValue <= 0 {
+		return "Target value must be greater than 0"
+	}
+	return ""
+}
+
+func keyResultProgress(keyResults []KeyResult) int {
+	total := 0.0
+	counted := 0
+	for _, kr := range keyResults {
+		if kr.TargetValue <= 0 {
+			continue
+		}
+		total += math.Min(kr.CurrentValue/kr.TargetValue, 1)
+		counted++
+	}
+	if counted == 0 {
+		return 0
+	}
+	return int(math.Round(total / float64(counted) * 100))
+}
+
+func CreateGoal(c *gin.Context) {
+	var goal Goal
+	if err := c.ShouldBindJSON(&goal); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	goal.ID = 0
+	goal.CheckIns = nil
+	for i := range goal.KeyResults {
+		goal.KeyResults[i].ID = 0
+		goal.KeyResults[i].GoalID = 0
+	}
+	if msg := validateGoal(&goal); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	for i := range goal.KeyResults {
+		if msg := validateKeyResult(&goal.KeyResults[i]); msg != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+			return
+		}
+	}
+
+	if !ownerExists(goal.OwnerType, goal.OwnerID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Goal owner not found"})
+		return
+	}
+
+	if len(goal.KeyResults) > 0 {
+		goal.Progress = keyResultProgress(goal.KeyResults)
+	}
+
+	if err := DB.Create(&goal).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, goal)
+}
+
+func GetGoals(c *gin.Context) {
+	query := DB.Preload("KeyResults")
+
+	if ownerType := c.Query("owner_type"); ownerType != "" {
+		query = query.Where("owner_type = ?", ownerType)
+	}
+
+	if ownerID := c.Query("owner_id"); ownerID != "" {
+		if id, err := strconv.Atoi(ownerID); err == nil {
+			query = query.Where("owner_id = ?", id)
+		}
+	}
+
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var goals []Goal
+	if err := query.Find(&goals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, goals)
+}
+
+func GetGoal(c *gin.Context) {
+	id := c.Param("id")
+	var goal Goal
+	if err := DB.Preload("KeyResults").Preload("CheckIns").First(&goal, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Goal not found"})
+		return
+	}
+	c.JSON(http.StatusOK, goal)
+}
+
+func UpdateGoal(c *gin.Context) {
+	id := c.Param("id")
+	var goal Goal
+	if err := DB.First(&goal, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Goal not found"})
+		return
+	}
+
+	var input Goal
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	goal.Title = input.Title
+	goal.Description = input.Description
+	goal.OwnerType = input.OwnerType
+	goal.OwnerID = input.OwnerID
+	goal.TargetDate = input.TargetDate
+	goal.Status = input.Status
+
+	var keyResultCount int64
+	if err := DB.Model(&KeyResult{}).Where("goal_id = ?", goal.ID).Count(&keyResultCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if keyResultCount == 0 {
+		goal.Progress = input.Progress
+	} else if input.Progress != 0 && input.Progress != goal.Progress {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Progress is computed from key results and cannot be set directly"})
+		return
+	}
+
+	if msg := validateGoal(&goal); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	if !ownerExists(goal.OwnerType, goal.OwnerID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Goal owner not found"})
+		return
+	}
+
+	if err := DB.Save(&goal).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, goal)
+}
+
+func DeleteGoal(c *gin.Context) {
+	id := c.Param("id")
+	var goal Goal
+	if err := DB.First(&goal, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Goal not found"})
+		return
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("goal_id = ?", goal.ID).Delete(&KeyResult{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("goal_id = ?", goal.ID).Delete(&GoalCheckIn{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&goal).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Goal deleted"})
+}
+
+func AddKeyResult(c *gin.Context) {
+	id := c.Param("id")
+	var goal Goal
+	if err := DB.First(&goal, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Goal not found"})
+		return
+	}
+
+	var keyResult KeyResult
+	if err := c.ShouldBindJSON(&keyResult); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if msg := validateKeyResult(&keyResult); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	keyResult.ID = 0
+	keyResult.GoalID = goal.ID
+	if err := DB.Create(&keyResult).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := refreshGoalProgress(&goal); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, keyResult)
+}
+
+func UpdateKeyResult(c *gin.Context) {
+	var keyResult KeyResult
+	if err := DB.Where("goal_id = ?", c.Param("id")).First(&keyResult, c.Param("krId")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Key result not found"})
+		return
+	}
+
+	var input KeyResult
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if input.Title != "" {
+		keyResult.Title = input.Title
+	}
+	keyResult.TargetValue = input.TargetValue
+	keyResult.CurrentValue = input.CurrentValue
+	keyResult.Unit = input.Unit
+
+	if msg := validateKeyResult(&keyResult); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	if err := DB.Save(&keyResult).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var goal Goal
+	if err := DB.First(&goal, keyResult.GoalID).Error; err == nil {
+		if err := refreshGoalProgress(&goal); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, keyResult)
+}
+
+func refreshGoalProgress(goal *Goal) error {
+	var keyResults []KeyResult
+	if err := DB.Where("goal_id = ?", goal.ID).Find(&keyResults).Error; err != nil {
+		return err
+	}
+	if len(keyResults) == 0 {
+		return nil
+	}
+	goal.Progress = keyResultProgress(keyResults)
+	return DB.Model(goal).Update("progress", goal.Progress).Error
+}
+
+func CreateGoalCheckIn(c *gin.Context) {
+	id := c.Param("id")
+	var goal Goal
+	if err := DB.Preload("KeyResults").First(&goal, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Goal not found"})
+		return
+	}
+
+	var request GoalCheckInRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if request.Status != "" && !goalStatuses[request.Status] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid goal status"})
+		return
+	}
+
+	for _, update := range request.KeyResults {
+		found := false
+		for i := range goal.KeyResults {
+			if goal.KeyResults[i].ID == update.ID {
+				goal.KeyResults[i].CurrentValue = update.CurrentValue
+				found = true
+			}
+		}
+		if !found {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Key result " + strconv.Itoa(int(update.ID)) + " does not belong to goal"})
+			return
+		}
+	}
+
+	if len(goal.KeyResults) > 0 {
+		goal.Progress = keyResultProgress(goal.KeyResults)
+	} else if request.Progress != nil {
+		if *request.Progress < 0 || *request.Progress > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Progress must be between 0 and 100"})
+			return
+		}
+		goal.Progress = *request.Progress
+	}
+
+	if request.Status != "" {
+		goal.Status = request.Status
+	} else if goal.Progress >= 100 {
+		goal.Status = "completed"
+	}
+
+	checkIn := GoalCheckIn{GoalID: goal.ID, Progress: goal.Progress, Status: goal.Status, Comment: request.Comment}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		for _, kr := range goal.KeyResults {
+			if err := tx.Model(&KeyResult{}).Where("id = ?", kr.ID).Update("current_value", kr.CurrentValue).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&goal).Updates(map[string]interface{}{"progress": goal.Progress, "status": goal.Status}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&checkIn).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, checkIn)
+}
+
+func GetGoalCheckIns(c *gin.Context) {
+	var checkIns []GoalCheckIn
+	if err := DB.Where("goal_id = ?", c.Param("id")).Order("created_at desc, id desc").Find(&checkIns).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, checkIns)
+}
+
+func averageProgress(goals []Goal) float64 {
+	if len(goals) == 0 {
+		return 0
+	}
+	total := 0
+	for _, goal := range goals {
+		total += goal.Progress
+	}
+	return math.Round(float64(total)/float64(len(goals))*10) / 10
+}
+
+func GetTeamGoalProgress(c *gin.Context) {
+	id := c.Param("id")
+	var team Team
+	if err := DB.Preload("Members").First(&team, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	var teamGoals []Goal
+	if err := DB.Where("owner_type = ? AND owner_id = ?", "team", team.ID).Find(&teamGoals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	allGoals := append([]Goal{}, teamGoals...)
+	members := []gin.H{}
+	for _, member := range team.Members {
+		var memberGoals []Goal
+		if err := DB.Where("owner_type = ? AND owner_id = ?", "member", member.ID).Find(&memberGoals).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		allGoals = append(allGoals, memberGoals...)
+		members = append(members, gin.H{
+			"member_id":        member.ID,
+			"name":             member.Name,
+			"goal_count":       len(memberGoals),
+			"average_progress": averageProgress(memberGoals),
+		})
+	}
+
+	completed := 0
+	for _, goal := range allGoals {
+		if goal.Status == "completed" {
+			completed++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team_id":              team.ID,
+		"team_goals":           teamGoals,
+		"team_goal_progress":   averageProgress(teamGoals),
+		"member_goal_progress": averageProgress(allGoals[len(teamGoals):]),
+		"members":              members,
+		"overall_progress":     averageProgress(allGoals),
+		"total_goals":          len(allGoals),
+		"completed_goals":      completed,
+	})
+}
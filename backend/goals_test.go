@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateGoal(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	t.Run("Create member goal with key results", func(t *testing.T) {
+		member := TeamMember{Name: "John Doe", Email: "john@example.com"}
+		DB.Create(&member)
+
+		body := `{"title": "Improve code reviews", "owner_type": "member", "owner_id": ` + strconv.Itoa(int(member.ID)) + `,
+			"key_results": [{"title": "Reviews per week", "target_value": 10, "current_value": 5}]}`
+		req, _ := http.NewRequest("POST", "/api/v1/goals", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var response Goal
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NotZero(t, response.ID)
+		assert.Equal(t, 50, response.Progress)
+		assert.Equal(t, "not_started", response.Status)
+		assert.Len(t, response.KeyResults, 1)
+	})
+
+	t.Run("Create goal with invalid owner type", func(t *testing.T) {
+		body := `{"title": "Ship it", "owner_type": "project", "owner_id": 1}`
+		req, _ := http.NewRequest("POST", "/api/v1/goals", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Create goal for non-existing owner", func(t *testing.T) {
+		body := `{"title": "Ship it", "owner_type": "team", "owner_id": 999}`
+		req, _ := http.NewRequest("POST", "/api/v1/goals", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Client supplied key result ids are ignored", func(t *testing.T) {
+		var existing KeyResult
+		DB.First(&existing)
+		member := TeamMember{Name: "Jane Doe", Email: "jane@example.com"}
+		DB.Create(&member)
+
+		body := `{"title": "Mentor juniors", "owner_type": "member", "owner_id": ` + strconv.Itoa(int(member.ID)) + `,
+			"key_results": [{"id": ` + strconv.Itoa(int(existing.ID)) + `, "goal_id": ` + strconv.Itoa(int(existing.GoalID)) + `, "title": "Pairing sessions", "target_value": 4}]}`
+		req, _ := http.NewRequest("POST", "/api/v1/goals", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response Goal
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NotEqual(t, existing.ID, response.KeyResults[0].ID)
+		assert.Equal(t, response.ID, response.KeyResults[0].GoalID)
+
+		var unchanged KeyResult
+		DB.First(&unchanged, existing.ID)
+		assert.Equal(t, "Reviews per week", unchanged.Title)
+	})
+
+	t.Run("Delete goal", func(t *testing.T) {
+		var goal Goal
+		DB.First(&goal)
+
+		req, _ := http.NewRequest("DELETE", "/api/v1/goals/"+strconv.Itoa(int(goal.ID)), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var count int64
+		DB.Model(&KeyResult{}).Where("goal_id = ?", goal.ID).Count(&count)
+		assert.Zero(t, count)
+
+		req, _ = http.NewRequest("DELETE", "/api/v1/goals/"+strconv.Itoa(int(goal.ID)), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestGoalProgressFromKeyResults(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	member := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	DB.Create(&member)
+	owner := strconv.Itoa(int(member.ID))
+
+	t.Run("Key results need a positive target", func(t *testing.T) {
+		w := performRequest(router, "POST", "/api/v1/goals", `{"title": "Ship", "owner_type": "member", "owner_id": `+owner+`,
+			"key_results": [{"title": "Done", "target_value": 0}]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	w := performRequest(router, "POST", "/api/v1/goals", `{"title": "Ship", "owner_type": "member", "owner_id": `+owner+`,
+		"key_results": [{"title": "Reviews", "target_value": 4, "current_value": 1}]}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var goal Goal
+	json.Unmarshal(w.Body.Bytes(), &goal)
+	goalURL := "/api/v1/goals/" + strconv.Itoa(int(goal.ID))
+
+	t.Run("Adding a key result with no target is rejected", func(t *testing.T) {
+		w := performRequest(router, "POST", goalURL+"/key-results", `{"title": "Docs", "target_value": -1}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Manual progress is rejected when key results exist", func(t *testing.T) {
+		w := performRequest(router, "PUT", goalURL, `{"title": "Ship", "owner_type": "member", "owner_id": `+owner+`, "progress": 90}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = performRequest(router, "PUT", goalURL, `{"title": "Ship it", "owner_type": "member", "owner_id": `+owner+`}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var updated Goal
+		json.Unmarshal(w.Body.Bytes(), &updated)
+		assert.Equal(t, 25, updated.Progress)
+	})
+}
+
+func TestGoalCheckIn(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	t.Run("Check-in updates progress and completes goal", func(t *testing.T) {
+		team := Team{Name: "Development Team"}
+		DB.Create(&team)
+		goal := Goal{Title: "Ship v2", OwnerType: "team", OwnerID: team.ID}
+		DB.Create(&goal)
+
+		body := `{"progress": 100, "comment": "Released!"}`
+		req, _ := http.NewRequest("POST", "/api/v1/goals/"+strconv.Itoa(int(goal.ID))+"/check-ins", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var updated Goal
+		DB.First(&updated, goal.ID)
+		assert.Equal(t, 100, updated.Progress)
+		assert.Equal(t, "completed", updated.Status)
+
+		req, _ = http.NewRequest("GET", "/api/v1/goals/"+strconv.Itoa(int(goal.ID))+"/check-ins", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var checkIns []GoalCheckIn
+		json.Unmarshal(w.Body.Bytes(), &checkIns)
+		assert.Len(t, checkIns, 1)
+		assert.Equal(t, "Released!", checkIns[0].Comment)
+	})
+
+	t.Run("Check-in with foreign key result", func(t *testing.T) {
+		goal := Goal{Title: "Other", OwnerType: "team", OwnerID: 1}
+		DB.Create(&goal)
+
+		body := `{"key_results": [{"id": 999, "current_value": 3}]}`
+		req, _ := http.NewRequest("POST", "/api/v1/goals/"+strconv.Itoa(int(goal.ID))+"/check-ins", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetTeamGoalProgress(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	t.Run("Roll up member goals into team progress", func(t *testing.T) {
+		member1 := TeamMember{Name: "John Doe", Email: "john@example.com"}
+		member2 := TeamMember{Name: "Jane Smith", Email: "jane@example.com"}
+		team := Team{Name: "Development Team"}
+		DB.Create(&member1)
+		DB.Create(&member2)
+		DB.Create(&team)
+		DB.Model(&team).Association("Members").Append(&member1, &member2)
+
+		DB.Create(&Goal{Title: "A", OwnerType: "member", OwnerID: member1.ID, Progress: 80})
+		DB.Create(&Goal{Title: "B", OwnerType: "member", OwnerID: member2.ID, Progress: 40})
+		DB.Create(&Goal{Title: "C", OwnerType: "team", OwnerID: team.ID, Progress: 30})
+
+		req, _ := http.NewRequest("GET", "/api/v1/teams/"+strconv.Itoa(int(team.ID))+"/goals/progress", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, float64(60), response["member_goal_progress"])
+		assert.Equal(t, float64(30), response["team_goal_progress"])
+		assert.Equal(t, float64(50), response["overall_progress"])
+		assert.Equal(t, float64(3), response["total_goals"])
+	})
+
+	t.Run("Progress for non-existing team", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/teams/999/goals/progress", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
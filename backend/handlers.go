@@ -1,32 +1,90 @@
 package main
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func CreateTeamMember(c *gin.Context) {
 	var member TeamMember
-	if err := c.ShouldBindJSON(&member); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &member) {
+		return
+	}
+	applyMemberDefaults(&member)
+
+	if emailInUse(member.Email, 0) {
+		respondUniqueConflict(c, "email")
+		return
+	}
+
+	if code, msg := validateManager(0, member.ManagerID); msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	changes, fieldErrors, err := validateCustomFields("member", member.CustomFields)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(fieldErrors) > 0 {
+		respondFieldErrors(c, fieldErrors)
 		return
 	}
 
-	if err := DB.Create(&member).Error; err != nil {
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&member).Error; err != nil {
+			return err
+		}
+		return saveCustomFields(tx, member.ID, changes)
+	})
+	if isUniqueViolation(err) {
+		respondUniqueConflict(c, "email")
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	fields, _ := loadCustomFields("member", []uint{member.ID})
+	member.CustomFields = fields[member.ID]
 	c.JSON(http.StatusCreated, member)
 }
 
 func GetTeamMembers(c *gin.Context) {
+	query := DB.Preload("Teams")
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	query, msg := customFieldFilters(c, "member", query)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
 	var members []TeamMember
-	if err := DB.Preload("Teams").Find(&members).Error; err != nil {
+	if err := query.Find(&members).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ids := make([]uint, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.ID)
+	}
+	fields, err := loadCustomFields("member", ids)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	for i := range members {
+		members[i].CustomFields = fields[members[i].ID]
+	}
 	c.JSON(http.StatusOK, members)
 }
 
@@ -37,6 +95,13 @@ func GetTeamMember(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
 		return
 	}
+
+	fields, err := loadCustomFields("member", []uint{member.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	member.CustomFields = fields[member.ID]
 	c.JSON(http.StatusOK, member)
 }
 
@@ -48,49 +113,171 @@ func UpdateTeamMember(c *gin.Context) {
 		return
 	}
 
-	if err := c.ShouldBindJSON(&member); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	input := member
+	if !bindJSON(c, &input) {
+		return
+	}
+	applyMemberDefaults(&input)
+
+	if emailInUse(input.Email, member.ID) {
+		respondUniqueConflict(c, "email")
+		return
+	}
+
+	if code, msg := validateManager(member.ID, input.ManagerID); msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	changes, fieldErrors, err := validateCustomFields("member", input.CustomFields)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(fieldErrors) > 0 {
+		respondFieldErrors(c, fieldErrors)
 		return
 	}
 
-	if err := DB.Save(&member).Error; err != nil {
+	member.Name = input.Name
+	member.Picture = input.Picture
+	member.Email = input.Email
+	member.JobTitle = input.JobTitle
+	member.Location = input.Location
+	member.Timezone = input.Timezone
+	member.StartDate = input.StartDate
+	member.Status = input.Status
+	member.ManagerID = input.ManagerID
+
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Teams").Save(&member).Error; err != nil {
+			return err
+		}
+		return saveCustomFields(tx, member.ID, changes)
+	})
+	if isUniqueViolation(err) {
+		respondUniqueConflict(c, "email")
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	fields, _ := loadCustomFields("member", []uint{member.ID})
+	member.CustomFields = fields[member.ID]
 	c.JSON(http.StatusOK, member)
 }
 
 func DeleteTeamMember(c *gin.Context) {
 	id := c.Param("id")
-	if err := DB.Delete(&TeamMember{}, id).Error; err != nil {
+	var member TeamMember
+	if err := DB.First(&member, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	policy, reassignTo, code, msg := parseFeedbackDeletePolicy(c, "member", member.ID)
+	if msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := applyFeedbackDeletePolicy(tx, "member", member.ID, policy, reassignTo); err != nil {
+			return err
+		}
+		if err := tx.Model(&Feedback{}).Where("author_id = ?", member.ID).Update("author_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&TeamMember{}).Where("manager_id = ?", member.ID).Update("manager_id", member.ManagerID).Error; err != nil {
+			return err
+		}
+		if err := closeMembershipPeriods(tx.Where("team_member_id = ?", member.ID), "member_deleted", time.Now()); err != nil {
+			return err
+		}
+		if err := removeApprover(tx, member.ID); err != nil {
+			return err
+		}
+		if err := deleteDependents(tx, "team_members", []uint{member.ID}); err != nil {
+			return err
+		}
+		return tx.Delete(&member).Error
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	deleteStoredImage(member.Picture)
 	c.JSON(http.StatusOK, gin.H{"message": "Team member deleted"})
 }
 
 func CreateTeam(c *gin.Context) {
 	var team Team
-	if err := c.ShouldBindJSON(&team); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &team) {
+		return
+	}
+
+	changes, fieldErrors, err := validateCustomFields("team", team.CustomFields)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(fieldErrors) > 0 {
+		respondFieldErrors(c, fieldErrors)
 		return
 	}
 
-	if err := DB.Create(&team).Error; err != nil {
+	var parentCode int
+	var parentMsg string
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if parentCode, parentMsg = validateTeamParent(tx, 0, team.ParentID); parentMsg != "" {
+			return errInvalidTeamParent
+		}
+		if err := tx.Create(&team).Error; err != nil {
+			return err
+		}
+		return saveCustomFields(tx, team.ID, changes)
+	})
+	if errors.Is(err, errInvalidTeamParent) {
+		c.JSON(parentCode, gin.H{"error": parentMsg})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	fields, _ := loadCustomFields("team", []uint{team.ID})
+	team.CustomFields = fields[team.ID]
 	c.JSON(http.StatusCreated, team)
 }
 
 func GetTeams(c *gin.Context) {
+	query, msg := customFieldFilters(c, "team", DB.Preload("Members"))
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
 	var teams []Team
-	if err := DB.Preload("Members").Find(&teams).Error; err != nil {
+	if err := query.Find(&teams).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ids := make([]uint, 0, len(teams))
+	for _, team := range teams {
+		ids = append(ids, team.ID)
+	}
+	fields, err := loadCustomFields("team", ids)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	for i := range teams {
+		teams[i].CustomFields = fields[teams[i].ID]
+	}
 	c.JSON(http.StatusOK, teams)
 }
 
@@ -101,6 +288,34 @@ func GetTeam(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
 		return
 	}
+
+	fields, err := loadCustomFields("team", []uint{team.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	team.CustomFields = fields[team.ID]
+
+	if value := c.Query("as_of"); value != "" {
+		asOf, err := parseAsOf(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "as_of must be a date (YYYY-MM-DD) or RFC3339 timestamp"})
+			return
+		}
+		if err := teamCompositionAsOf(&team, asOf); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, team)
+		return
+	}
+
+	memberships, err := teamMemberships(team.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	team.Memberships = memberships
 	c.JSON(http.StatusOK, team)
 }
 
@@ -112,25 +327,84 @@ func UpdateTeam(c *gin.Context) {
 		return
 	}
 
-	if err := c.ShouldBindJSON(&team); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	input := team
+	if !bindJSON(c, &input) {
 		return
 	}
 
-	if err := DB.Save(&team).Error; err != nil {
+	changes, fieldErrors, err := validateCustomFields("team", input.CustomFields)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(fieldErrors) > 0 {
+		respondFieldErrors(c, fieldErrors)
+		return
+	}
+
+	team.Name = input.Name
+	team.Logo = input.Logo
+	team.ParentID = input.ParentID
+
+	var parentCode int
+	var parentMsg string
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if parentCode, parentMsg = validateTeamParent(tx, team.ID, team.ParentID); parentMsg != "" {
+			return errInvalidTeamParent
+		}
+		if err := tx.Omit("Members").Save(&team).Error; err != nil {
+			return err
+		}
+		return saveCustomFields(tx, team.ID, changes)
+	})
+	if errors.Is(err, errInvalidTeamParent) {
+		c.JSON(parentCode, gin.H{"error": parentMsg})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	fields, _ := loadCustomFields("team", []uint{team.ID})
+	team.CustomFields = fields[team.ID]
 	c.JSON(http.StatusOK, team)
 }
 
 func DeleteTeam(c *gin.Context) {
 	id := c.Param("id")
-	if err := DB.Delete(&Team{}, id).Error; err != nil {
+	var team Team
+	if err := DB.First(&team, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	policy, reassignTo, code, msg := parseFeedbackDeletePolicy(c, "team", team.ID)
+	if msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := applyFeedbackDeletePolicy(tx, "team", team.ID, policy, reassignTo); err != nil {
+			return err
+		}
+		if err := tx.Model(&Team{}).Where("parent_id = ?", team.ID).Update("parent_id", team.ParentID).Error; err != nil {
+			return err
+		}
+		if err := closeMembershipPeriods(tx.Where("team_id = ?", team.ID), "team_deleted", time.Now()); err != nil {
+			return err
+		}
+		if err := deleteDependents(tx, "teams", []uint{team.ID}); err != nil {
+			return err
+		}
+		return tx.Delete(&team).Error
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	deleteStoredImage(team.Logo)
 	c.JSON(http.StatusOK, gin.H{"message": "Team deleted"})
 }
 
@@ -141,6 +415,11 @@ func AssignToTeam(c *gin.Context) {
 		return
 	}
 
+	if msg := validateTeamAssignment(&assignment); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
 	var team Team
 	var member TeamMember
 	
@@ -154,12 +433,18 @@ func AssignToTeam(c *gin.Context) {
 		return
 	}
 
-	if err := DB.Model(&team).Association("Members").Append(&member); err != nil {
+	var membership *TeamMembership
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		membership, err = saveTeamMembership(tx, &assignment)
+		return err
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Member assigned to team successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "Member assigned to team successfully", "membership": membership})
 }
 
 func RemoveFromTeam(c *gin.Context) {
@@ -179,7 +464,14 @@ func RemoveFromTeam(c *gin.Context) {
 		return
 	}
 
-	if err := DB.Model(&team).Association("Members").Delete(&member); err != nil {
+	reason := c.DefaultQuery("reason", "removed")
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&team).Association("Members").Delete(&member); err != nil {
+			return err
+		}
+		return closeMembershipPeriods(tx.Where("team_id = ? AND team_member_id = ?", team.ID, member.ID), reason, time.Now())
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -187,6 +479,61 @@ func RemoveFromTeam(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Member removed from team successfully"})
 }
 
+func prepareNewFeedback(feedback *Feedback) (*FeedbackApproval, int, string) {
+	if _, ok := feedbackTargetTypes[feedback.TargetType]; !ok {
+		return nil, http.StatusBadRequest, invalidTargetTypeMessage()
+	}
+
+	if code, msg := validateFeedbackStructure(feedback, nil); msg != "" {
+		return nil, code, msg
+	}
+
+	if !validFeedbackRating(feedback.Rating) {
+		return nil, http.StatusBadRequest, "Rating must be between 1 and 5"
+	}
+
+	if !feedbackTargetExists(DB, feedback.TargetType, feedback.TargetID) {
+		return nil, http.StatusNotFound, targetNotFoundMessage(feedback.TargetType)
+	}
+
+	if len(feedback.Competencies) > 0 && feedback.TargetType != "member" {
+		return nil, http.StatusBadRequest, "Competency ratings are only allowed on member feedback"
+	}
+
+	if msg := validateCompetencyRatings(feedback.Competencies, feedback.TargetID); msg != "" {
+		return nil, http.StatusBadRequest, msg
+	}
+
+	if feedback.Anonymous && feedback.AuthorID == nil {
+		return nil, http.StatusBadRequest, "Anonymous feedback requires an author"
+	}
+	if feedback.Anonymous && len(anonymityKey()) == 0 {
+		return nil, http.StatusServiceUnavailable, "Anonymous feedback is disabled until FEEDBACK_ANONYMITY_KEY is set"
+	}
+
+	if feedback.AuthorID != nil {
+		var author TeamMember
+		if err := DB.First(&author, *feedback.AuthorID).Error; err != nil {
+			return nil, http.StatusNotFound, "Author not found"
+		}
+	}
+
+	if msg := validateFeedbackDelivery(feedback, time.Now()); msg != "" {
+		return nil, http.StatusBadRequest, msg
+	}
+
+	approval, msg := prepareFeedbackApproval(feedback)
+	if msg != "" {
+		return nil, http.StatusConflict, msg
+	}
+
+	scoreFeedback(feedback)
+	if feedback.Anonymous {
+		anonymize(feedback)
+	}
+	return approval, 0, ""
+}
+
 func CreateFeedback(c *gin.Context) {
 	var feedback Feedback
 	if err := c.ShouldBindJSON(&feedback); err != nil {
@@ -194,12 +541,29 @@ func CreateFeedback(c *gin.Context) {
 		return
 	}
 
-	if feedback.TargetType != "team" && feedback.TargetType != "member" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Target type must be 'team' or 'member'"})
+	feedback.ID = 0
+	feedback.FeedbackRequestID = nil
+	feedback.AcknowledgedAt = nil
+	feedback.AcknowledgedBy = nil
+	feedback.ArchivedAt = nil
+	feedback.CreatedAt = time.Time{}
+	feedback.UpdatedAt = time.Time{}
+	approval, code, msg := prepareNewFeedback(&feedback)
+	if msg != "" {
+		c.JSON(code, gin.H{"error": msg})
 		return
 	}
 
-	if err := DB.Create(&feedback).Error; err != nil {
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&feedback).Error; err != nil {
+			return err
+		}
+		if approval == nil {
+			return nil
+		}
+		return createFeedbackApproval(tx, &feedback, approval)
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -210,9 +574,10 @@ func CreateFeedback(c *gin.Context) {
 func GetFeedback(c *gin.Context) {
 	targetType := c.Query("target_type")
 	targetID := c.Query("target_id")
+	kind := c.Query("kind")
 
 	var feedbacks []Feedback
-	query := DB.Model(&Feedback{})
+	query := DB.Model(&Feedback{}).Scopes(revealedFeedback, visibleFeedback(viewerFromQuery(c))).Preload("Competencies")
 
 	if targetType != "" {
 		query = query.Where("target_type = ?", targetType)
@@ -224,6 +589,14 @@ func GetFeedback(c *gin.Context) {
 		}
 	}
 
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	if c.Query("include_archived") != "true" {
+		query = query.Scopes(activeFeedback)
+	}
+
 	if err := query.Find(&feedbacks).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -233,18 +606,97 @@ func GetFeedback(c *gin.Context) {
 }
 
 func GetFeedbackByID(c *gin.Context) {
+	id := c.Param("id")
+	var feedback Feedback
+	if err := DB.Scopes(revealedFeedback, visibleFeedback(viewerFromQuery(c))).Preload("Competencies").First(&feedback, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback not found"})
+		return
+	}
+	c.JSON(http.StatusOK, feedback)
+}
+
+func UpdateFeedback(c *gin.Context) {
 	id := c.Param("id")
 	var feedback Feedback
 	if err := DB.First(&feedback, id).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Feedback not found"})
 		return
 	}
+
+	if feedback.Anonymous {
+		c.JSON(http.StatusConflict, gin.H{"error": "Anonymous feedback cannot be edited"})
+		return
+	}
+
+	if msg := feedbackEditConflict(&feedback); msg != "" {
+		c.JSON(http.StatusConflict, gin.H{"error": msg})
+		return
+	}
+
+	var input struct {
+		Content   string `json:"content"`
+		Kind      string `json:"kind"`
+		Situation string `json:"situation"`
+		Behavior  string `json:"behavior"`
+		Impact    string `json:"impact"`
+		Rating    *int   `json:"rating"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	before := feedback
+	feedback.Content = input.Content
+	feedback.Kind = input.Kind
+	feedback.Situation = input.Situation
+	feedback.Behavior = input.Behavior
+	feedback.Impact = input.Impact
+	feedback.Rating = input.Rating
+
+	if code, msg := validateFeedbackStructure(&feedback, before.TemplateID); msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	if !validFeedbackRating(feedback.Rating) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Rating must be between 1 and 5"})
+		return
+	}
+
+	var approval *FeedbackApproval
+	if feedbackWordingChanged(&before, &feedback) {
+		var msg string
+		if approval, msg = prepareFeedbackApproval(&feedback); msg != "" {
+			c.JSON(http.StatusConflict, gin.H{"error": msg})
+			return
+		}
+	}
+
+	scoreFeedback(&feedback)
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Competencies").Save(&feedback).Error; err != nil {
+			return err
+		}
+		if approval == nil {
+			return nil
+		}
+		return createFeedbackApproval(tx, &feedback, approval)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, feedback)
 }
 
 func DeleteFeedback(c *gin.Context) {
 	id := c.Param("id")
-	if err := DB.Delete(&Feedback{}, id).Error; err != nil {
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		return deleteFeedbackRecords(tx, tx.Model(&Feedback{}).Select("id").Where("id = ?", id))
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
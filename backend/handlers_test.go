@@ -25,6 +25,24 @@ func setupTestRouter() *gin.Engine {
 			members.GET("/:id", GetTeamMember)
 			members.PUT("/:id", UpdateTeamMember)
 			members.DELETE("/:id", DeleteTeamMember)
+			members.GET("/:id/action-items", GetMemberActionItems)
+			members.GET("/:id/feedback-requests", GetPendingFeedbackRequests)
+			members.GET("/:id/notifications", GetMemberNotifications)
+			members.GET("/:id/competencies", GetMemberCompetencyProfile)
+			members.PUT("/:id/competency-expectations", SetCompetencyExpectations)
+			members.GET("/search", SearchMembersBySkill)
+			members.GET("/:id/skills", GetMemberSkills)
+			members.GET("/:id/sentiment", GetMemberSentiment)
+			members.GET("/:id/unacknowledged-feedback", GetUnacknowledgedFeedback)
+			members.GET("/:id/drafts", GetMemberDrafts)
+			members.GET("/:id/pending-approvals", GetPendingApprovals)
+			members.GET("/:id/memberships", GetMemberMemberships)
+			members.GET("/:id/team-history", GetMemberTeamHistory)
+			members.GET("/:id/reports", GetDirectReports)
+			members.POST("/:id/picture", UploadMemberPicture)
+			members.GET("/:id/avatar", GetMemberAvatar)
+			members.PUT("/:id/skills", SetMemberSkill)
+			members.DELETE("/:id/skills/:skillId", DeleteMemberSkill)
 		}
 
 		teams := api.Group("/teams")
@@ -34,6 +52,15 @@ func setupTestRouter() *gin.Engine {
 			teams.GET("/:id", GetTeam)
 			teams.PUT("/:id", UpdateTeam)
 			teams.DELETE("/:id", DeleteTeam)
+			teams.GET("/:id/goals/progress", GetTeamGoalProgress)
+			teams.GET("/:id/health", GetTeamHealth)
+			teams.GET("/:id/skills-matrix", GetTeamSkillsMatrix)
+			teams.GET("/:id/sentiment", GetTeamSentiment)
+			teams.POST("/:id/logo", UploadTeamLogo)
+			teams.GET("/:id/avatar", GetTeamAvatar)
+			teams.GET("/:id/subtree", GetTeamSubtree)
+			teams.GET("/:id/ancestors", GetTeamAncestors)
+			teams.GET("/:id/feedback/rollup", GetTeamFeedbackRollup)
 		}
 		
 		api.DELETE("/remove-member/:teamId/:memberId", RemoveFromTeam)
@@ -45,13 +72,168 @@ func setupTestRouter() *gin.Engine {
 			feedback.POST("", CreateFeedback)
 			feedback.GET("", GetFeedback)
 			feedback.GET("/:id", GetFeedbackByID)
+			feedback.PUT("/:id", UpdateFeedback)
+			feedback.POST("/:id/replies", CreateFeedbackReply)
+			feedback.GET("/:id/replies", GetFeedbackReplies)
+			feedback.POST("/:id/acknowledge", AcknowledgeFeedback)
+			feedback.POST("/:id/publish", PublishFeedback)
+			feedback.GET("/:id/approval", GetFeedbackApproval)
+			feedback.POST("/:id/approval", DecideFeedbackApproval)
+			feedback.POST("/:id/resubmit", ResubmitFeedback)
 			feedback.DELETE("/:id", DeleteFeedback)
 		}
+
+		feedbackTemplates := api.Group("/feedback-templates")
+		{
+			feedbackTemplates.POST("", CreateFeedbackTemplate)
+			feedbackTemplates.GET("", GetFeedbackTemplates)
+			feedbackTemplates.GET("/:id", GetFeedbackTemplate)
+			feedbackTemplates.PUT("/:id", UpdateFeedbackTemplate)
+			feedbackTemplates.DELETE("/:id", DeleteFeedbackTemplate)
+		}
+
+		projects := api.Group("/projects")
+		{
+			projects.POST("", CreateProject)
+			projects.GET("", GetProjects)
+			projects.GET("/:id", GetProject)
+			projects.PUT("/:id", UpdateProject)
+			projects.DELETE("/:id", DeleteProject)
+		}
+
+		goals := api.Group("/goals")
+		{
+			goals.POST("", CreateGoal)
+			goals.GET("", GetGoals)
+			goals.GET("/:id", GetGoal)
+			goals.PUT("/:id", UpdateGoal)
+			goals.DELETE("/:id", DeleteGoal)
+			goals.POST("/:id/key-results", AddKeyResult)
+			goals.PUT("/:id/key-results/:krId", UpdateKeyResult)
+			goals.POST("/:id/check-ins", CreateGoalCheckIn)
+			goals.GET("/:id/check-ins", GetGoalCheckIns)
+		}
+
+		actionItems := api.Group("/action-items")
+		{
+			actionItems.POST("", CreateActionItem)
+			actionItems.GET("", GetActionItems)
+			actionItems.GET("/overdue", GetOverdueActionItems)
+			actionItems.GET("/:id", GetActionItem)
+			actionItems.PUT("/:id", UpdateActionItem)
+			actionItems.POST("/:id/status", ChangeActionItemStatus)
+			actionItems.DELETE("/:id", DeleteActionItem)
+		}
+
+		reviewCycles := api.Group("/review-cycles")
+		{
+			reviewCycles.POST("", CreateReviewCycle)
+			reviewCycles.GET("", GetReviewCycles)
+			reviewCycles.GET("/:id", GetReviewCycle)
+			reviewCycles.PUT("/:id", UpdateReviewCycle)
+			reviewCycles.DELETE("/:id", DeleteReviewCycle)
+			reviewCycles.POST("/:id/transition", TransitionReviewCycle)
+			reviewCycles.GET("/:id/participants", GetReviewCycleParticipants)
+			reviewCycles.POST("/:id/reviews", SubmitReview)
+			reviewCycles.GET("/:id/reviews", GetReviews)
+			reviewCycles.GET("/:id/packets/:memberId", GetReviewPacket)
+		}
+
+		feedbackRequests := api.Group("/feedback-requests")
+		{
+			feedbackRequests.POST("", CreateFeedbackRequest)
+			feedbackRequests.GET("", GetFeedbackRequests)
+			feedbackRequests.GET("/:id", GetFeedbackRequest)
+			feedbackRequests.POST("/:id/respond", RespondToFeedbackRequest)
+			feedbackRequests.POST("/:id/decline", DeclineFeedbackRequest)
+			feedbackRequests.POST("/:id/remind", RemindFeedbackRequest)
+		}
+
+		api.POST("/notifications/:id/read", MarkNotificationRead)
+
+		surveyTemplates := api.Group("/survey-templates")
+		{
+			surveyTemplates.POST("", CreateSurveyTemplate)
+			surveyTemplates.GET("", GetSurveyTemplates)
+			surveyTemplates.GET("/:id", GetSurveyTemplate)
+			surveyTemplates.DELETE("/:id", DeleteSurveyTemplate)
+		}
+
+		surveyRuns := api.Group("/survey-runs")
+		{
+			surveyRuns.POST("", CreateSurveyRun)
+			surveyRuns.GET("", GetSurveyRuns)
+			surveyRuns.GET("/:id", GetSurveyRun)
+			surveyRuns.POST("/:id/responses", SubmitSurveyResponse)
+			surveyRuns.POST("/:id/close", CloseSurveyRun)
+			surveyRuns.GET("/:id/results", GetSurveyRunResults)
+		}
+
+		retrospectives := api.Group("/retrospectives")
+		{
+			retrospectives.POST("", CreateRetrospective)
+			retrospectives.GET("", GetRetrospectives)
+			retrospectives.GET("/:id", GetRetrospective)
+			retrospectives.POST("/:id/cards", AddRetroCard)
+			retrospectives.POST("/:id/cards/:cardId/votes", VoteRetroCard)
+			retrospectives.DELETE("/:id/cards/:cardId/votes/:memberId", RemoveRetroVote)
+			retrospectives.POST("/:id/cards/:cardId/group", GroupRetroCard)
+			retrospectives.POST("/:id/action-items", CreateRetroActionItem)
+			retrospectives.POST("/:id/close", CloseRetrospective)
+		}
+
+		competencies := api.Group("/competencies")
+		{
+			competencies.POST("", CreateCompetency)
+			competencies.GET("", GetCompetencies)
+			competencies.GET("/:id", GetCompetency)
+			competencies.PUT("/:id", UpdateCompetency)
+			competencies.DELETE("/:id", DeleteCompetency)
+		}
+
+		customFields := api.Group("/custom-fields")
+		{
+			customFields.POST("", CreateCustomField)
+			customFields.GET("", GetCustomFields)
+			customFields.DELETE("/:id", DeleteCustomField)
+		}
+
+		approvalPolicies := api.Group("/approval-policies")
+		{
+			approvalPolicies.POST("", CreateApprovalPolicy)
+			approvalPolicies.GET("", GetApprovalPolicies)
+			approvalPolicies.DELETE("/:id", DeleteApprovalPolicy)
+		}
+
+		api.GET("/orgchart", GetOrgChart)
+		api.GET("/files/*key", ServeFile)
+
+		analytics := api.Group("/analytics")
+		{
+			analytics.GET("/feedback-volume", GetFeedbackVolume)
+			analytics.GET("/silent-members", GetSilentMembers)
+			analytics.GET("/top-authors", GetTopAuthors)
+			analytics.GET("/feedback-kinds", GetFeedbackKindRatio)
+		}
+
+		skills := api.Group("/skills")
+		{
+			skills.POST("", CreateSkill)
+			skills.GET("", GetSkills)
+		}
 	}
 
 	return r
 }
 
+func performRequest(router http.Handler, method, url, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
 func TestCreateTeamMember(t *testing.T) {
 	DB = SetupTestDB()
 	defer CleanupTestDB(DB)
@@ -89,7 +271,14 @@ func TestCreateTeamMember(t *testing.T) {
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
-		assert.True(t, w.Code == http.StatusInternalServerError || w.Code == http.StatusCreated)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		var response struct {
+			Fields map[string]string `json:"fields"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "is required", response.Fields["name"])
+		assert.Equal(t, "must be a valid email address", response.Fields["email"])
 	})
 
 	t.Run("Create team member with malformed JSON", func(t *testing.T) {
@@ -282,7 +471,7 @@ func TestCreateTeam(t *testing.T) {
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
 
-		assert.True(t, w.Code == http.StatusInternalServerError || w.Code == http.StatusCreated)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 	})
 }
 
@@ -362,11 +551,14 @@ func TestCreateFeedback(t *testing.T) {
 
 	router := setupTestRouter()
 
+	member := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	DB.Create(&member)
+
 	t.Run("Create feedback successfully", func(t *testing.T) {
 		feedback := Feedback{
 			Content:    "Great work!",
-			TargetType: "person",
-			TargetID:   1,
+			TargetType: "member",
+			TargetID:   member.ID,
 		}
 
 		jsonValue, _ := json.Marshal(feedback)
@@ -381,7 +573,7 @@ func TestCreateFeedback(t *testing.T) {
 		var response Feedback
 		json.Unmarshal(w.Body.Bytes(), &response)
 		assert.Equal(t, "Great work!", response.Content)
-		assert.Equal(t, "person", response.TargetType)
+		assert.Equal(t, "member", response.TargetType)
 		assert.NotZero(t, response.ID)
 	})
 
@@ -403,7 +595,24 @@ func TestCreateFeedback(t *testing.T) {
 
 		var response map[string]string
 		json.Unmarshal(w.Body.Bytes(), &response)
-		assert.Equal(t, "Target type must be 'team' or 'member'", response["error"])
+		assert.Equal(t, "Target type must be 'member', 'project' or 'team'", response["error"])
+	})
+
+	t.Run("Create feedback for missing target", func(t *testing.T) {
+		feedback := Feedback{
+			Content:    "Great work!",
+			TargetType: "team",
+			TargetID:   999,
+		}
+
+		jsonValue, _ := json.Marshal(feedback)
+		req, _ := http.NewRequest("POST", "/api/v1/feedback", bytes.NewBuffer(jsonValue))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
 }
 
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	identiconGrid        = 5
+	defaultIdenticonSize = 128
+	minIdenticonSize     = 16
+	maxIdenticonSize     = 512
+	avatarCacheControl   = "public, max-age=86400"
+)
+
+var identiconBackground = color.NRGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}
+
+type identicon struct {
+	hash  [sha256.Size]byte
+	cells [identiconGrid][identiconGrid]bool
+	color color.NRGBA
+}
+
+func newIdenticon(seed string) identicon {
+	icon := identicon{hash: sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(seed))))}
+	half := (identiconGrid + 1) / 2
+	for row := 0; row < identiconGrid; row++ {
+		for col := 0; col < half; col++ {
+			filled := icon.hash[row*half+col]%2 == 0
+			icon.cells[row][col] = filled
+			icon.cells[row][identiconGrid-1-col] = filled
+		}
+	}
+	hue := float64(int(icon.hash[29])<<8|int(icon.hash[30])) / 65536 * 360
+	icon.color = hslColor(hue, 0.55, 0.5)
+	return icon
+}
+
+func hslColor(hue, saturation, lightness float64) color.NRGBA {
+	chroma := (1 - math.Abs(2*lightness-1)) * saturation
+	x := chroma * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	m := lightness - chroma/2
+
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g = chroma, x
+	case hue < 120:
+		r, g = x, chroma
+	case hue < 180:
+		g, b = chroma, x
+	case hue < 240:
+		g, b = x, chroma
+	case hue < 300:
+		r, b = x, chroma
+	default:
+		r, b = chroma, x
+	}
+	return color.NRGBA{
+		R: uint8(math.Round((r + m) * 255)),
+		G: uint8(math.Round((g + m) * 255)),
+		B: uint8(math.Round((b + m) * 255)),
+		A: 0xff,
+	}
+}
+
+func (icon identicon) svg(size int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="-0.5 -0.5 6 6" shape-rendering="crispEdges">`, size, size)
+	fmt.Fprintf(&b, `<rect x="-0.5" y="-0.5" width="6" height="6" fill="#%02x%02x%02x"/>`, identiconBackground.R, identiconBackground.G, identiconBackground.B)
+	fmt.Fprintf(&b, `<g fill="#%02x%02x%02x">`, icon.color.R, icon.color.G, icon.color.B)
+	for row := 0; row < identiconGrid; row++ {
+		for col := 0; col < identiconGrid; col++ {
+			if icon.cells[row][col] {
+				fmt.Fprintf(&b, `<rect x="%d" y="%d" width="1" height="1"/>`, col, row)
+			}
+		}
+	}
+	b.WriteString("</g></svg>")
+	return b.String()
+}
+
+func (icon identicon) png(size int) ([]byte, error) {
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	scale := float64(identiconGrid+1) / float64(size)
+	for y := 0; y < size; y++ {
+		row := int(math.Floor((float64(y)+0.5)*scale - 0.5))
+		for x := 0; x < size; x++ {
+			col := int(math.Floor((float64(x)+0.5)*scale - 0.5))
+			if row >= 0 && col >= 0 && row < identiconGrid && col < identiconGrid && icon.cells[row][col] {
+				img.SetNRGBA(x, y, icon.color)
+			} else {
+				img.SetNRGBA(x, y, identiconBackground)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func serveAvatar(c *gin.Context, picture string, seed string) {
+	if _, ok := storedImageKey(picture); ok {
+		c.Redirect(http.StatusFound, picture)
+		return
+	}
+
+	size := defaultIdenticonSize
+	if value := c.Query("size"); value != "" {
+		var err error
+		if size, err = strconv.Atoi(value); err != nil || size < minIdenticonSize || size > maxIdenticonSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("size must be between %d and %d", minIdenticonSize, maxIdenticonSize)})
+			return
+		}
+	}
+	format := c.DefaultQuery("format", "svg")
+	if format != "svg" && format != "png" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Format must be 'svg' or 'png'"})
+		return
+	}
+
+	icon := newIdenticon(seed)
+	etag := fmt.Sprintf(`"%s-%s-%d"`, hex.EncodeToString(icon.hash[:8]), format, size)
+	c.Header("Cache-Control", avatarCacheControl)
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	if format == "svg" {
+		c.Data(http.StatusOK, "image/svg+xml", []byte(icon.svg(size)))
+		return
+	}
+	data, err := icon.png(size)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(http.StatusOK, "image/png", data)
+}
+
+func GetMemberAvatar(c *gin.Context) {
+	var member TeamMember
+	if err := DB.First(&member, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+	serveAvatar(c, member.Picture, member.Email)
+}
+
+func GetTeamAvatar(c *gin.Context) {
+	var team Team
+	if err := DB.First(&team, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+	serveAvatar(c, team.Logo, team.Name)
+}
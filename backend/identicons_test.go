@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdenticon(t *testing.T) {
+	t.Run("Deterministic and case-insensitive", func(t *testing.T) {
+		assert.Equal(t, newIdenticon("ada@example.com"), newIdenticon(" ADA@example.com "))
+		assert.NotEqual(t, newIdenticon("ada@example.com").hash, newIdenticon("bob@example.com").hash)
+	})
+
+	t.Run("Horizontally symmetric", func(t *testing.T) {
+		icon := newIdenticon("ada@example.com")
+		for row := 0; row < identiconGrid; row++ {
+			for col := 0; col < identiconGrid; col++ {
+				assert.Equal(t, icon.cells[row][col], icon.cells[row][identiconGrid-1-col])
+			}
+		}
+	})
+
+	t.Run("PNG has the requested size", func(t *testing.T) {
+		data, err := newIdenticon("ada@example.com").png(64)
+		assert.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 64, img.Bounds().Dx())
+		assert.Equal(t, 64, img.Bounds().Dy())
+		r, g, b, _ := img.At(0, 0).RGBA()
+		assert.Equal(t, []uint32{0xf0f0, 0xf0f0, 0xf0f0}, []uint32{r, g, b})
+	})
+}
+
+func TestAvatarEndpoints(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	plain := TeamMember{Name: "Plain", Email: "plain@example.com"}
+	pictured := TeamMember{Name: "Pictured", Email: "pictured@example.com", Picture: "/api/v1/files/members/2/abc.png"}
+	external := TeamMember{Name: "External", Email: "external@example.com", Picture: "https://evil.example.com/avatar.png"}
+	DB.Create(&plain)
+	DB.Create(&pictured)
+	DB.Create(&external)
+	team := Team{Name: "Platform"}
+	DB.Create(&team)
+
+	get := func(url string, headers map[string]string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", url, nil)
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	plainURL := "/api/v1/members/" + strconv.Itoa(int(plain.ID)) + "/avatar"
+
+	t.Run("SVG identicon for members without a picture", func(t *testing.T) {
+		w := get(plainURL, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/svg+xml", w.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(w.Body.String(), "<svg"))
+		assert.Equal(t, newIdenticon(plain.Email).svg(defaultIdenticonSize), w.Body.String())
+		assert.NotEmpty(t, w.Header().Get("Cache-Control"))
+
+		assert.Equal(t, http.StatusNotModified, get(plainURL, map[string]string{"If-None-Match": w.Header().Get("ETag")}).Code)
+	})
+
+	t.Run("PNG identicon with size", func(t *testing.T) {
+		w := get(plainURL+"?format=png&size=32", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+		img, err := png.Decode(w.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, 32, img.Bounds().Dx())
+
+		assert.Equal(t, http.StatusBadRequest, get(plainURL+"?size=4000", nil).Code)
+		assert.Equal(t, http.StatusBadRequest, get(plainURL+"?format=gif", nil).Code)
+	})
+
+	t.Run("Redirect to an existing picture", func(t *testing.T) {
+		w := get("/api/v1/members/"+strconv.Itoa(int(pictured.ID))+"/avatar", nil)
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, pictured.Picture, w.Header().Get("Location"))
+	})
+
+	t.Run("External pictures fall back to the identicon", func(t *testing.T) {
+		w := get("/api/v1/members/"+strconv.Itoa(int(external.ID))+"/avatar", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Location"))
+		assert.Equal(t, newIdenticon(external.Email).svg(defaultIdenticonSize), w.Body.String())
+	})
+
+	t.Run("Team logo identicon", func(t *testing.T) {
+		w := get("/api/v1/teams/"+strconv.Itoa(int(team.ID))+"/avatar", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, newIdenticon("platform").svg(defaultIdenticonSize), w.Body.String())
+
+		assert.Equal(t, http.StatusNotFound, get("/api/v1/teams/9999/avatar", nil).Code)
+	})
+}
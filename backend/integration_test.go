@@ -40,7 +40,7 @@ func (suite *IntegrationTestSuite) TestCompleteWorkflow() {
 	assert.Len(suite.T(), team.Members, 2)
 	
 	suite.createFeedback("Great teamwork!", "team", teamID)
-	suite.createFeedback("Excellent individual contribution!", "person", member1ID)
+	suite.createFeedback("Excellent individual contribution!", "member", member1ID)
 	
 	feedbacks := suite.getAllFeedback()
 	assert.Len(suite.T(), feedbacks, 2)
@@ -49,7 +49,7 @@ func (suite *IntegrationTestSuite) TestCompleteWorkflow() {
 	assert.Len(suite.T(), teamFeedbacks, 1)
 	assert.Equal(suite.T(), "Great teamwork!", teamFeedbacks[0].Content)
 	
-	personFeedbacks := suite.getFeedbackByTargetType("person")
+	personFeedbacks := suite.getFeedbackByTargetType("member")
 	assert.Len(suite.T(), personFeedbacks, 1)
 	assert.Equal(suite.T(), "Excellent individual contribution!", personFeedbacks[0].Content)
 }
@@ -123,19 +123,19 @@ func (suite *IntegrationTestSuite) TestFeedbackManagement() {
 	memberID := suite.createTeamMember("Test User", "test@example.com", "test.jpg")
 	teamID := suite.createTeam("Test Team", "test-logo.png")
 	
-	feedback1ID := suite.createFeedback("Individual feedback", "person", memberID)
+	feedback1ID := suite.createFeedback("Individual feedback", "member", memberID)
 	_ = suite.createFeedback("Team feedback", "team", teamID)
 	
 	feedback1 := suite.getFeedbackByID(feedback1ID)
 	assert.Equal(suite.T(), "Individual feedback", feedback1.Content)
-	assert.Equal(suite.T(), "person", feedback1.TargetType)
+	assert.Equal(suite.T(), "member", feedback1.TargetType)
 	assert.Equal(suite.T(), memberID, feedback1.TargetID)
 	
-	memberFeedbacks := suite.getFeedbackByTargetID(memberID)
+	memberFeedbacks := suite.getFeedbackByTarget("member", memberID)
 	assert.Len(suite.T(), memberFeedbacks, 1)
 	assert.Equal(suite.T(), "Individual feedback", memberFeedbacks[0].Content)
 	
-	teamFeedbacks := suite.getFeedbackByTargetID(teamID)
+	teamFeedbacks := suite.getFeedbackByTarget("team", teamID)
 	assert.Len(suite.T(), teamFeedbacks, 1)
 	assert.Equal(suite.T(), "Team feedback", teamFeedbacks[0].Content)
 	
@@ -332,8 +332,8 @@ func (suite *IntegrationTestSuite) getFeedbackByTargetType(targetType string) []
 	return feedbacks
 }
 
-func (suite *IntegrationTestSuite) getFeedbackByTargetID(targetID uint) []Feedback {
-	req, _ := http.NewRequest("GET", "/api/v1/feedback?target_id="+strconv.Itoa(int(targetID)), nil)
+func (suite *IntegrationTestSuite) getFeedbackByTarget(targetType string, targetID uint) []Feedback {
+	req, _ := http.NewRequest("GET", "/api/v1/feedback?target_type="+targetType+"&target_id="+strconv.Itoa(int(targetID)), nil)
 	w := httptest.NewRecorder()
 	suite.router.ServeHTTP(w, req)
 	
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var feedbackDeletePolicies = map[string]bool{
+	"cascade":  true,
+	"archive":  true,
+	"reassign": true,
+}
+
+type IntegrityReport struct {
+	OrphanedTargets []uint           `json:"orphaned_targets"`
+	OrphanedAuthors []uint           `json:"orphaned_authors"`
+	OrphanedRows    map[string]int64 `json:"orphaned_rows"`
+	Fixed           bool             `json:"fixed"`
+}
+
+type dependentReference struct {
+	Table   string
+	Column  string
+	Parent  string
+	Scope   string
+	Nullify bool
+}
+
+var dependentReferences = []dependentReference{
+	{Table: "member_teams", Column: "team_member_id", Parent: "team_members"},
+	{Table: "project_members", Column: "team_member_id", Parent: "team_members"},
+	{Table: "goals", Column: "owner_id", Parent: "team_members", Scope: "owner_type = 'member'"},
+	{Table: "action_items", Column: "assignee_id", Parent: "team_members"},
+	{Table: "reviews", Column: "reviewer_id", Parent: "team_members", Nullify: true},
+	{Table: "reviews", Column: "reviewee_id", Parent: "team_members"},
+	{Table: "feedback_requests", Column: "requester_id", Parent: "team_members", Nullify: true},
+	{Table: "feedback_requests", Column: "target_member_id", Parent: "team_members"},
+	{Table: "feedback_request_recipients", Column: "recipient_id", Parent: "team_members"},
+	{Table: "notifications", Column: "member_id", Parent: "team_members"},
+	{Table: "survey_responses", Column: "member_id", Parent: "team_members", Nullify: true},
+	{Table: "retro_votes", Column: "member_id", Parent: "team_members"},
+	{Table: "retro_cards", Column: "author_id", Parent: "team_members", Nullify: true},
+	{Table: "competency_ratings", Column: "member_id", Parent: "team_members"},
+	{Table: "competency_expectations", Column: "member_id", Parent: "team_members"},
+	{Table: "member_skills", Column: "member_id", Parent: "team_members"},
+	{Table: "feedback_replies", Column: "author_id", Parent: "team_members"},
+	{Table: "approval_policies", Column: "approver_id", Parent: "team_members", Nullify: true},
+	{Table: "feedback_approvals", Column: "decided_by", Parent: "team_members", Nullify: true},
+	{Table: "feedback_approval_events", Column: "actor_id", Parent: "team_members", Nullify: true},
+	{Table: "custom_field_values", Column: "entity_id", Parent: "team_members",
+		Scope: "definition_id IN (SELECT id FROM custom_field_definitions WHERE entity_type = 'member')"},
+	{Table: "member_teams", Column: "team_id", Parent: "teams"},
+	{Table: "project_teams", Column: "team_id", Parent: "teams"},
+	{Table: "review_cycle_teams", Column: "team_id", Parent: "teams"},
+	{Table: "goals", Column: "owner_id", Parent: "teams", Scope: "owner_type = 'team'"},
+	{Table: "survey_runs", Column: "team_id", Parent: "teams"},
+	{Table: "retrospectives", Column: "team_id", Parent: "teams"},
+	{Table: "custom_field_values", Column: "entity_id", Parent: "teams",
+		Scope: "definition_id IN (SELECT id FROM custom_field_definitions WHERE entity_type = 'team')"},
+	{Table: "key_results", Column: "goal_id", Parent: "goals"},
+	{Table: "goal_check_ins", Column: "goal_id", Parent: "goals"},
+	{Table: "competency_ratings", Column: "review_id", Parent: "reviews"},
+	{Table: "feedbacks", Column: "feedback_request_id", Parent: "feedback_requests", Nullify: true},
+	{Table: "feedback_request_recipients", Column: "feedback_request_id", Parent: "feedback_requests"},
+	{Table: "survey_responses", Column: "survey_run_id", Parent: "survey_runs"},
+	{Table: "survey_answers", Column: "survey_response_id", Parent: "survey_responses"},
+	{Table: "action_items", Column: "retrospective_id", Parent: "retrospectives", Nullify: true},
+	{Table: "retro_cards", Column: "retrospective_id", Parent: "retrospectives"},
+	{Table: "retro_columns", Column: "retrospective_id", Parent: "retrospectives"},
+	{Table: "retro_cards", Column: "group_id", Parent: "retro_cards", Nullify: true},
+	{Table: "retro_votes", Column: "retro_card_id", Parent: "retro_cards"},
+	{Table: "feedback_replies", Column: "parent_id", Parent: "feedback_replies"},
+}
+
+func (ref dependentReference) key() string {
+	return ref.Table + "." + ref.Column
+}
+
+func (ref dependentReference) scoped(db *gorm.DB) *gorm.DB {
+	query := db.Table(ref.Table)
+	if ref.Scope != "" {
+		query = query.Where(ref.Scope)
+	}
+	return query
+}
+
+func (ref dependentReference) orphaned(db *gorm.DB) *gorm.DB {
+	return ref.scoped(db).Where(ref.Column+" IS NOT NULL AND "+ref.Column+" NOT IN (?)", db.Table(ref.Parent).Select("id"))
+}
+
+func hasDependents(table string) bool {
+	for _, ref := range dependentReferences {
+		if ref.Parent == table {
+			return true
+		}
+	}
+	return false
+}
+
+func removeDependentRows(tx *gorm.DB, ref dependentReference, rows *gorm.DB) error {
+	if ref.Nullify {
+		return rows.Update(ref.Column, nil).Error
+	}
+	if !hasDependents(ref.Table) {
+		return rows.Delete(map[string]interface{}{}).Error
+	}
+
+	var ids []uint
+	if err := rows.Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := deleteDependents(tx, ref.Table, ids); err != nil {
+		return err
+	}
+	return tx.Table(ref.Table).Where("id IN ?", ids).Delete(map[string]interface{}{}).Error
+}
+
+func deleteDependents(tx *gorm.DB, parent string, ids []uint) error {
+	for _, ref := range dependentReferences {
+		if ref.Parent != parent {
+			continue
+		}
+		if err := removeDependentRows(tx, ref, ref.scoped(tx).Where(ref.Column+" IN ?", ids)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteFeedbackRecords(tx *gorm.DB, ids *gorm.DB) error {
+	approvals := tx.Model(&FeedbackApproval{}).Select("id").Where("feedback_id IN (?)", ids)
+	if err := tx.Where("feedback_approval_id IN (?)", approvals).Delete(&FeedbackApprovalEvent{}).Error; err != nil {
+		return err
+	}
+	for _, model := range []interface{}{&FeedbackApproval{}, &FeedbackReply{}, &CompetencyRating{}} {
+		if err := tx.Where("feedback_id IN (?)", ids).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Model(&ActionItem{}).Where("feedback_id IN (?)", ids).Update("feedback_id", nil).Error; err != nil {
+		return err
+	}
+	return tx.Where("id IN (?)", ids).Delete(&Feedback{}).Error
+}
+
+func applyFeedbackDeletePolicy(tx *gorm.DB, targetType string, targetID uint, policy string, reassignTo uint) error {
+	feedbacks := tx.Model(&Feedback{}).Where("target_type = ? AND target_id = ?", targetType, targetID)
+	switch policy {
+	case "cascade":
+		return deleteFeedbackRecords(tx, tx.Model(&Feedback{}).Select("id").Where("target_type = ? AND target_id = ?", targetType, targetID))
+	case "reassign":
+		if err := tx.Model(&Feedback{}).Where("target_type = ? AND target_id = ? AND anonymous = ? AND archived_at IS NULL", targetType, targetID, true).
+			Update("archived_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return feedbacks.Where("anonymous = ?", false).Update("target_id", reassignTo).Error
+	default:
+		return feedbacks.Where("archived_at IS NULL").Update("archived_at", time.Now()).Error
+	}
+}
+
+func parseFeedbackDeletePolicy(c *gin.Context, targetType string, targetID uint) (string, uint, int, string) {
+	policy := c.DefaultQuery("feedback", "archive")
+	if !feedbackDeletePolicies[policy] {
+		return "", 0, http.StatusBadRequest, "feedback must be 'cascade', 'archive' or 'reassign'"
+	}
+	if policy != "reassign" {
+		return policy, 0, 0, ""
+	}
+
+	id, err := strconv.Atoi(c.Query("reassign_to"))
+	if err != nil || id <= 0 {
+		return "", 0, http.StatusBadRequest, "reassign_to is required when reassigning feedback"
+	}
+	reassignTo := uint(id)
+	if reassignTo == targetID {
+		return "", 0, http.StatusBadRequest, "Feedback cannot be reassigned to the deleted " + targetType
+	}
+	if !feedbackTargetExists(DB, targetType, reassignTo) {
+		return "", 0, http.StatusNotFound, targetNotFoundMessage(targetType)
+	}
+	return policy, reassignTo, 0, ""
+}
+
+func activeFeedback(db *gorm.DB) *gorm.DB {
+	return db.Where("feedbacks.archived_at IS NULL")
+}
+
+func CheckFeedbackIntegrity(db *gorm.DB, fix bool) (IntegrityReport, error) {
+	report := IntegrityReport{OrphanedTargets: []uint{}, OrphanedAuthors: []uint{}, OrphanedRows: map[string]int64{}}
+
+	orphaned := db.Where("target_type NOT IN ?", feedbackTargetTypeNames())
+	for _, name := range feedbackTargetTypeNames() {
+		orphaned = orphaned.Or("target_type = ? AND target_id NOT IN (?)", name, db.Model(feedbackTargetTypes[name].Model).Select("id"))
+	}
+	if err := db.Model(&Feedback{}).Where("archived_at IS NULL").Where(orphaned).
+		Order("id").Pluck("id", &report.OrphanedTargets).Error; err != nil {
+		return report, err
+	}
+
+	if err := db.Model(&Feedback{}).
+		Where("author_id IS NOT NULL AND author_id NOT IN (?)", db.Model(&TeamMember{}).Select("id")).
+		Order("id").Pluck("id", &report.OrphanedAuthors).Error; err != nil {
+		return report, err
+	}
+
+	for _, ref := range dependentReferences {
+		var count int64
+		if err := ref.orphaned(db).Count(&count).Error; err != nil {
+			return report, err
+		}
+		if count > 0 {
+			report.OrphanedRows[ref.key()] += count
+		}
+	}
+
+	if !fix || (len(report.OrphanedTargets) == 0 && len(report.OrphanedAuthors) == 0 && len(report.OrphanedRows) == 0) {
+		return report, nil
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if len(report.OrphanedTargets) > 0 {
+			if err := tx.Model(&Feedback{}).Where("id IN ?", report.OrphanedTargets).Update("archived_at", time.Now()).Error; err != nil {
+				return err
+			}
+		}
+		if len(report.OrphanedAuthors) > 0 {
+			if err := tx.Model(&Feedback{}).Where("id IN ?", report.OrphanedAuthors).Update("author_id", nil).Error; err != nil {
+				return err
+			}
+		}
+		for _, ref := range dependentReferences {
+			if report.OrphanedRows[ref.key()] == 0 {
+				continue
+			}
+			if err := removeDependentRows(tx, ref, ref.orphaned(tx)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	report.Fixed = err == nil
+	return report, err
+}
+
+func RunIntegrityCheck(args []string) error {
+	fix := false
+	for _, arg := range args {
+		switch arg {
+		case "--fix":
+			fix = true
+		default:
+			return errors.New("unknown argument " + arg)
+		}
+	}
+
+	report, err := CheckFeedbackIntegrity(DB, fix)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Feedback with a missing target: %d %v\n", len(report.OrphanedTargets), report.OrphanedTargets)
+	fmt.Printf("Feedback with a missing author: %d %v\n", len(report.OrphanedAuthors), report.OrphanedAuthors)
+	for _, ref := range dependentReferences {
+		if count := report.OrphanedRows[ref.key()]; count > 0 {
+			fmt.Printf("Rows in %s pointing at a missing %s: %d\n", ref.key(), ref.Parent, count)
+		}
+	}
+	if report.Fixed {
+		fmt.Println("Archived feedback with a missing target, cleared missing authors and removed orphaned rows")
+	} else if len(report.OrphanedTargets)+len(report.OrphanedAuthors)+len(report.OrphanedRows) > 0 {
+		fmt.Println("Run with --fix to archive feedback with a missing target, clear missing authors and remove orphaned rows")
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteTargetFeedbackPolicies(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	del := func(url string) int {
+		req, _ := http.NewRequest("DELETE", url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("Archive by default", func(t *testing.T) {
+		member := TeamMember{Name: "Alice", Email: "alice@example.com"}
+		DB.Create(&member)
+		feedback := Feedback{Content: "Good", TargetType: "member", TargetID: member.ID}
+		DB.Create(&feedback)
+
+		assert.Equal(t, http.StatusOK, del("/api/v1/members/"+strconv.Itoa(int(member.ID))))
+
+		var stored Feedback
+		DB.First(&stored, feedback.ID)
+		assert.NotNil(t, stored.ArchivedAt)
+
+		req, _ := http.NewRequest("GET", "/api/v1/feedback", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.NotContains(t, w.Body.String(), `"content":"Good"`)
+	})
+
+	t.Run("Cascade deletes feedback and replies", func(t *testing.T) {
+		member := TeamMember{Name: "Bob", Email: "bob@example.com"}
+		DB.Create(&member)
+		feedback := Feedback{Content: "Bye", TargetType: "member", TargetID: member.ID}
+		DB.Create(&feedback)
+		DB.Create(&FeedbackReply{FeedbackID: feedback.ID, AuthorID: member.ID, Content: "Thanks"})
+
+		assert.Equal(t, http.StatusOK, del("/api/v1/members/"+strconv.Itoa(int(member.ID))+"?feedback=cascade"))
+
+		var count int64
+		DB.Model(&Feedback{}).Where("id = ?", feedback.ID).Count(&count)
+		assert.Equal(t, int64(0), count)
+		DB.Model(&FeedbackReply{}).Where("feedback_id = ?", feedback.ID).Count(&count)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("Reassign team feedback", func(t *testing.T) {
+		oldTeam := Team{Name: "Old"}
+		newTeam := Team{Name: "New"}
+		DB.Create(&oldTeam)
+		DB.Create(&newTeam)
+		feedback := Feedback{Content: "Solid sprint", TargetType: "team", TargetID: oldTeam.ID}
+		anonymous := Feedback{Content: "Quiet", TargetType: "team", TargetID: oldTeam.ID, Anonymous: true, ContributorHash: "old-target-hash"}
+		DB.Create(&feedback)
+		DB.Create(&anonymous)
+
+		url := "/api/v1/teams/" + strconv.Itoa(int(oldTeam.ID)) + "?feedback=reassign"
+		assert.Equal(t, http.StatusBadRequest, del(url))
+		assert.Equal(t, http.StatusNotFound, del(url+"&reassign_to=999"))
+		assert.Equal(t, http.StatusOK, del(url+"&reassign_to="+strconv.Itoa(int(newTeam.ID))))
+
+		var stored Feedback
+		DB.First(&stored, feedback.ID)
+		assert.Equal(t, newTeam.ID, stored.TargetID)
+		assert.Nil(t, stored.ArchivedAt)
+
+		var archived Feedback
+		DB.First(&archived, anonymous.ID)
+		assert.Equal(t, oldTeam.ID, archived.TargetID)
+		assert.NotNil(t, archived.ArchivedAt)
+	})
+
+	t.Run("Reject unknown policy", func(t *testing.T) {
+		team := Team{Name: "Other"}
+		DB.Create(&team)
+		assert.Equal(t, http.StatusBadRequest, del("/api/v1/teams/"+strconv.Itoa(int(team.ID))+"?feedback=drop"))
+	})
+
+	t.Run("Deleting a missing member", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, del("/api/v1/members/999"))
+	})
+}
+
+func TestCheckFeedbackIntegrity(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	member := TeamMember{Name: "Alice", Email: "alice@example.com"}
+	DB.Create(&member)
+	missingAuthor := uint(999)
+	valid := Feedback{Content: "Fine", TargetType: "member", TargetID: member.ID}
+	orphanTarget := Feedback{Content: "Lost", TargetType: "team", TargetID: 42}
+	orphanAuthor := Feedback{Content: "Who", TargetType: "member", TargetID: member.ID, AuthorID: &missingAuthor}
+	DB.Create(&valid)
+	DB.Create(&orphanTarget)
+	DB.Create(&orphanAuthor)
+
+	report, err := CheckFeedbackIntegrity(DB, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{orphanTarget.ID}, report.OrphanedTargets)
+	assert.Equal(t, []uint{orphanAuthor.ID}, report.OrphanedAuthors)
+	assert.False(t, report.Fixed)
+
+	report, err = CheckFeedbackIntegrity(DB, true)
+	assert.NoError(t, err)
+	assert.True(t, report.Fixed)
+
+	report, err = CheckFeedbackIntegrity(DB, false)
+	assert.NoError(t, err)
+	assert.Empty(t, report.OrphanedTargets)
+	assert.Empty(t, report.OrphanedAuthors)
+
+	var archived Feedback
+	DB.First(&archived, orphanTarget.ID)
+	assert.NotNil(t, archived.ArchivedAt)
+}
+
+func TestDeleteMemberAndTeamRemovesDependents(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	alice := TeamMember{Name: "Alice", Email: "alice@example.com"}
+	bob := TeamMember{Name: "Bob", Email: "bob@example.com"}
+	DB.Create(&alice)
+	DB.Create(&bob)
+	team := Team{Name: "Platform", Members: []TeamMember{alice, bob}}
+	other := Team{Name: "Payments"}
+	DB.Create(&team)
+	DB.Create(&other)
+	joined := time.Now().AddDate(0, -1, 0)
+	DB.Create(&MembershipPeriod{TeamMemberID: alice.ID, TeamID: team.ID, Role: "member", Allocation: 100, JoinedAt: joined})
+
+	project := Project{Name: "Launch", Members: []TeamMember{alice}, Teams: []Team{team}}
+	DB.Create(&project)
+	cycle := ReviewCycle{Name: "H1", PeriodStart: time.Now().AddDate(0, -6, 0), PeriodEnd: time.Now(), Teams: []Team{team}}
+	DB.Create(&cycle)
+	aboutAlice := Review{ReviewCycleID: cycle.ID, Type: "peer", ReviewerID: &bob.ID, RevieweeID: alice.ID}
+	byAlice := Review{ReviewCycleID: cycle.ID, Type: "peer", ReviewerID: &alice.ID, RevieweeID: bob.ID}
+	DB.Create(&aboutAlice)
+	DB.Create(&byAlice)
+	goal := Goal{Title: "Ship", OwnerType: "member", OwnerID: alice.ID, KeyResults: []KeyResult{{Title: "Beta", TargetValue: 1}}}
+	DB.Create(&goal)
+	DB.Create(&ActionItem{Title: "Follow up", AssigneeID: alice.ID})
+	DB.Create(&Notification{MemberID: alice.ID, Kind: "review", Message: "Review ready"})
+	aboutAliceRequest := FeedbackRequest{RequesterID: &bob.ID, TargetMemberID: alice.ID, Recipients: []FeedbackRequestRecipient{{RecipientID: bob.ID}}}
+	byAliceRequest := FeedbackRequest{RequesterID: &alice.ID, TargetMemberID: bob.ID}
+	DB.Create(&aboutAliceRequest)
+	DB.Create(&byAliceRequest)
+	run := SurveyRun{SurveyTemplateID: 1, TeamID: other.ID}
+	DB.Create(&run)
+	response := SurveyResponse{SurveyRunID: run.ID, MemberID: &alice.ID}
+	DB.Create(&response)
+	feedback := Feedback{Content: "Careful", TargetType: "member", TargetID: bob.ID, Status: "pending_approval"}
+	DB.Create(&feedback)
+	approval := FeedbackApproval{FeedbackID: feedback.ID, RequestedStatus: "published", Approvers: []uint{alice.ID, 42}}
+	DB.Create(&approval)
+
+	del := func(url string) int {
+		req, _ := http.NewRequest("DELETE", url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	count := func(table string) int64 {
+		var n int64
+		DB.Table(table).Count(&n)
+		return n
+	}
+
+	assert.Equal(t, http.StatusOK, del("/api/v1/members/"+strconv.Itoa(int(alice.ID))))
+	for _, table := range []string{"project_members", "goals", "key_results", "action_items", "notifications", "feedback_request_recipients"} {
+		assert.Zero(t, count(table), table)
+	}
+	assert.Equal(t, int64(1), count("member_teams"))
+
+	t.Run("History written by the member is kept", func(t *testing.T) {
+		var reviews []Review
+		DB.Find(&reviews)
+		assert.Len(t, reviews, 1)
+		assert.Equal(t, byAlice.ID, reviews[0].ID)
+		assert.Nil(t, reviews[0].ReviewerID)
+
+		var requests []FeedbackRequest
+		DB.Find(&requests)
+		assert.Len(t, requests, 1)
+		assert.Nil(t, requests[0].RequesterID)
+
+		var storedResponse SurveyResponse
+		assert.NoError(t, DB.First(&storedResponse, response.ID).Error)
+		assert.Nil(t, storedResponse.MemberID)
+
+		var period MembershipPeriod
+		assert.NoError(t, DB.Where("team_member_id = ?", alice.ID).First(&period).Error)
+		assert.NotNil(t, period.LeftAt)
+		assert.Equal(t, "member_deleted", period.Reason)
+
+		req, _ := http.NewRequest("GET", "/api/v1/teams/"+strconv.Itoa(int(team.ID))+"?as_of="+joined.Add(time.Hour).Format(time.RFC3339), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var composition Team
+		json.Unmarshal(w.Body.Bytes(), &composition)
+		assert.Len(t, composition.Memberships, 1)
+		assert.Equal(t, alice.ID, composition.Memberships[0].TeamMemberID)
+	})
+
+	t.Run("Deleted members are removed from pending approvals", func(t *testing.T) {
+		var stored FeedbackApproval
+		DB.First(&stored, approval.ID)
+		assert.Equal(t, []uint{42}, stored.Approvers)
+	})
+
+	assert.Equal(t, http.StatusOK, del("/api/v1/teams/"+strconv.Itoa(int(team.ID))))
+	for _, table := range []string{"project_teams", "review_cycle_teams", "member_teams"} {
+		assert.Zero(t, count(table), table)
+	}
+	assert.Equal(t, int64(1), count("membership_periods"))
+
+	report, err := CheckFeedbackIntegrity(DB, false)
+	assert.NoError(t, err)
+	assert.Empty(t, report.OrphanedRows)
+
+	DB.Exec("INSERT INTO project_members (project_id, team_member_id) VALUES (?, ?)", project.ID, 999)
+	DB.Create(&Review{ReviewCycleID: cycle.ID, Type: "peer", ReviewerID: &bob.ID, RevieweeID: 999})
+
+	report, err = CheckFeedbackIntegrity(DB, true)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"project_members.team_member_id": 1, "reviews.reviewee_id": 1}, report.OrphanedRows)
+	assert.True(t, report.Fixed)
+
+	report, err = CheckFeedbackIntegrity(DB, false)
+	assert.NoError(t, err)
+	assert.Empty(t, report.OrphanedRows)
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"log"
+	"os"
 	"time"
+	_ "time/tzdata"
 	
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -11,6 +13,20 @@ import (
 func main() {
 	InitDatabase()
 
+	if len(os.Args) > 1 && os.Args[1] == "integrity-check" {
+		if err := RunIntegrityCheck(os.Args[2:]); err != nil {
+			log.Fatal("Integrity check failed:", err)
+		}
+		return
+	}
+
+	InitStorage()
+	StartScheduler()
+
+	if len(anonymityKey()) == 0 {
+		log.Println("FEEDBACK_ANONYMITY_KEY is not set, anonymous feedback is disabled")
+	}
+
 	r := gin.Default()
 
 	// CORS middleware
@@ -32,6 +48,24 @@ func main() {
 			members.GET("/:id", GetTeamMember)
 			members.PUT("/:id", UpdateTeamMember)
 			members.DELETE("/:id", DeleteTeamMember)
+			members.GET("/:id/action-items", GetMemberActionItems)
+			members.GET("/:id/feedback-requests", GetPendingFeedbackRequests)
+			members.GET("/:id/notifications", GetMemberNotifications)
+			members.GET("/:id/competencies", GetMemberCompetencyProfile)
+			members.PUT("/:id/competency-expectations", SetCompetencyExpectations)
+			members.GET("/search", SearchMembersBySkill)
+			members.GET("/:id/skills", GetMemberSkills)
+			members.GET("/:id/sentiment", GetMemberSentiment)
+			members.GET("/:id/unacknowledged-feedback", GetUnacknowledgedFeedback)
+			members.GET("/:id/drafts", GetMemberDrafts)
+			members.GET("/:id/pending-approvals", GetPendingApprovals)
+			members.GET("/:id/memberships", GetMemberMemberships)
+			members.GET("/:id/team-history", GetMemberTeamHistory)
+			members.GET("/:id/reports", GetDirectReports)
+			members.POST("/:id/picture", UploadMemberPicture)
+			members.GET("/:id/avatar", GetMemberAvatar)
+			members.PUT("/:id/skills", SetMemberSkill)
+			members.DELETE("/:id/skills/:skillId", DeleteMemberSkill)
 		}
 
 		teams := api.Group("/teams")
@@ -41,6 +75,15 @@ func main() {
 			teams.GET("/:id", GetTeam)
 			teams.PUT("/:id", UpdateTeam)
 			teams.DELETE("/:id", DeleteTeam)
+			teams.GET("/:id/goals/progress", GetTeamGoalProgress)
+			teams.GET("/:id/health", GetTeamHealth)
+			teams.GET("/:id/skills-matrix", GetTeamSkillsMatrix)
+			teams.GET("/:id/sentiment", GetTeamSentiment)
+			teams.POST("/:id/logo", UploadTeamLogo)
+			teams.GET("/:id/avatar", GetTeamAvatar)
+			teams.GET("/:id/subtree", GetTeamSubtree)
+			teams.GET("/:id/ancestors", GetTeamAncestors)
+			teams.GET("/:id/feedback/rollup", GetTeamFeedbackRollup)
 		}
 		
 		api.DELETE("/remove-member/:teamId/:memberId", RemoveFromTeam)
@@ -52,8 +95,155 @@ func main() {
 			feedback.POST("", CreateFeedback)
 			feedback.GET("", GetFeedback)
 			feedback.GET("/:id", GetFeedbackByID)
+			feedback.PUT("/:id", UpdateFeedback)
+			feedback.POST("/:id/replies", CreateFeedbackReply)
+			feedback.GET("/:id/replies", GetFeedbackReplies)
+			feedback.POST("/:id/acknowledge", AcknowledgeFeedback)
+			feedback.POST("/:id/publish", PublishFeedback)
+			feedback.GET("/:id/approval", GetFeedbackApproval)
+			feedback.POST("/:id/approval", DecideFeedbackApproval)
+			feedback.POST("/:id/resubmit", ResubmitFeedback)
 			feedback.DELETE("/:id", DeleteFeedback)
 		}
+
+		feedbackTemplates := api.Group("/feedback-templates")
+		{
+			feedbackTemplates.POST("", CreateFeedbackTemplate)
+			feedbackTemplates.GET("", GetFeedbackTemplates)
+			feedbackTemplates.GET("/:id", GetFeedbackTemplate)
+			feedbackTemplates.PUT("/:id", UpdateFeedbackTemplate)
+			feedbackTemplates.DELETE("/:id", DeleteFeedbackTemplate)
+		}
+
+		projects := api.Group("/projects")
+		{
+			projects.POST("", CreateProject)
+			projects.GET("", GetProjects)
+			projects.GET("/:id", GetProject)
+			projects.PUT("/:id", UpdateProject)
+			projects.DELETE("/:id", DeleteProject)
+		}
+
+		goals := api.Group("/goals")
+		{
+			goals.POST("", CreateGoal)
+			goals.GET("", GetGoals)
+			goals.GET("/:id", GetGoal)
+			goals.PUT("/:id", UpdateGoal)
+			goals.DELETE("/:id", DeleteGoal)
+			goals.POST("/:id/key-results", AddKeyResult)
+			goals.PUT("/:id/key-results/:krId", UpdateKeyResult)
+			goals.POST("/:id/check-ins", CreateGoalCheckIn)
+			goals.GET("/:id/check-ins", GetGoalCheckIns)
+		}
+
+		actionItems := api.Group("/action-items")
+		{
+			actionItems.POST("", CreateActionItem)
+			actionItems.GET("", GetActionItems)
+			actionItems.GET("/overdue", GetOverdueActionItems)
+			actionItems.GET("/:id", GetActionItem)
+			actionItems.PUT("/:id", UpdateActionItem)
+			actionItems.POST("/:id/status", ChangeActionItemStatus)
+			actionItems.DELETE("/:id", DeleteActionItem)
+		}
+
+		reviewCycles := api.Group("/review-cycles")
+		{
+			reviewCycles.POST("", CreateReviewCycle)
+			reviewCycles.GET("", GetReviewCycles)
+			reviewCycles.GET("/:id", GetReviewCycle)
+			reviewCycles.PUT("/:id", UpdateReviewCycle)
+			reviewCycles.DELETE("/:id", DeleteReviewCycle)
+			reviewCycles.POST("/:id/transition", TransitionReviewCycle)
+			reviewCycles.GET("/:id/participants", GetReviewCycleParticipants)
+			reviewCycles.POST("/:id/reviews", SubmitReview)
+			reviewCycles.GET("/:id/reviews", GetReviews)
+			reviewCycles.GET("/:id/packets/:memberId", GetReviewPacket)
+		}
+
+		feedbackRequests := api.Group("/feedback-requests")
+		{
+			feedbackRequests.POST("", CreateFeedbackRequest)
+			feedbackRequests.GET("", GetFeedbackRequests)
+			feedbackRequests.GET("/:id", GetFeedbackRequest)
+			feedbackRequests.POST("/:id/respond", RespondToFeedbackRequest)
+			feedbackRequests.POST("/:id/decline", DeclineFeedbackRequest)
+			feedbackRequests.POST("/:id/remind", RemindFeedbackRequest)
+		}
+
+		api.POST("/notifications/:id/read", MarkNotificationRead)
+
+		surveyTemplates := api.Group("/survey-templates")
+		{
+			surveyTemplates.POST("", CreateSurveyTemplate)
+			surveyTemplates.GET("", GetSurveyTemplates)
+			surveyTemplates.GET("/:id", GetSurveyTemplate)
+			surveyTemplates.DELETE("/:id", DeleteSurveyTemplate)
+		}
+
+		surveyRuns := api.Group("/survey-runs")
+		{
+			surveyRuns.POST("", CreateSurveyRun)
+			surveyRuns.GET("", GetSurveyRuns)
+			surveyRuns.GET("/:id", GetSurveyRun)
+			surveyRuns.POST("/:id/responses", SubmitSurveyResponse)
+			surveyRuns.POST("/:id/close", CloseSurveyRun)
+			surveyRuns.GET("/:id/results", GetSurveyRunResults)
+		}
+
+		retrospectives := api.Group("/retrospectives")
+		{
+			retrospectives.POST("", CreateRetrospective)
+			retrospectives.GET("", GetRetrospectives)
+			retrospectives.GET("/:id", GetRetrospective)
+			retrospectives.POST("/:id/cards", AddRetroCard)
+			retrospectives.POST("/:id/cards/:cardId/votes", VoteRetroCard)
+			retrospectives.DELETE("/:id/cards/:cardId/votes/:memberId", RemoveRetroVote)
+			retrospectives.POST("/:id/cards/:cardId/group", GroupRetroCard)
+			retrospectives.POST("/:id/action-items", CreateRetroActionItem)
+			retrospectives.POST("/:id/close", CloseRetrospective)
+		}
+
+		competencies := api.Group("/competencies")
+		{
+			competencies.POST("", CreateCompetency)
+			competencies.GET("", GetCompetencies)
+			competencies.GET("/:id", GetCompetency)
+			competencies.PUT("/:id", UpdateCompetency)
+			competencies.DELETE("/:id", DeleteCompetency)
+		}
+
+		customFields := api.Group("/custom-fields")
+		{
+			customFields.POST("", CreateCustomField)
+			customFields.GET("", GetCustomFields)
+			customFields.DELETE("/:id", DeleteCustomField)
+		}
+
+		approvalPolicies := api.Group("/approval-policies")
+		{
+			approvalPolicies.POST("", CreateApprovalPolicy)
+			approvalPolicies.GET("", GetApprovalPolicies)
+			approvalPolicies.DELETE("/:id", DeleteApprovalPolicy)
+		}
+
+		api.GET("/orgchart", GetOrgChart)
+		api.GET("/files/*key", ServeFile)
+
+		analytics := api.Group("/analytics")
+		{
+			analytics.GET("/feedback-volume", GetFeedbackVolume)
+			analytics.GET("/silent-members", GetSilentMembers)
+			analytics.GET("/top-authors", GetTopAuthors)
+			analytics.GET("/feedback-kinds", GetFeedbackKindRatio)
+		}
+
+		skills := api.Group("/skills")
+		{
+			skills.POST("", CreateSkill)
+			skills.GET("", GetSkills)
+		}
 	}
 
 	log.Println("Server starting on port 8080")
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const defaultTeamRole = "member"
+
+var teamRoles = map[string]bool{
+	"lead":     true,
+	"member":   true,
+	"coach":    true,
+	"observer": true,
+}
+
+func setupJoinTables(db *gorm.DB) error {
+	if err := db.SetupJoinTable(&Team{}, "Members", &TeamMembership{}); err != nil {
+		return err
+	}
+	return db.SetupJoinTable(&TeamMember{}, "Teams", &TeamMembership{})
+}
+
+func teamMemberships(teamID uint) ([]TeamMembership, error) {
+	var memberships []TeamMembership
+	err := DB.Where("team_id = ?", teamID).Order("joined_at, team_member_id").Find(&memberships).Error
+	return memberships, err
+}
+
+func teamLeadIDs(memberID uint) []uint {
+	var ids []uint
+	DB.Model(&TeamMembership{}).
+		Where("role = ? AND team_id IN (?)", "lead", DB.Model(&TeamMembership{}).Select("team_id").Where("team_member_id = ?", memberID)).
+		Distinct().Pluck("team_member_id", &ids)
+	return ids
+}
+
+func validateTeamAssignment(assignment *TeamAssignment) string {
+	if assignment.Role == "" {
+		assignment.Role = defaultTeamRole
+	}
+	if !teamRoles[assignment.Role] {
+		return "Role must be 'lead', 'member', 'coach' or 'observer'"
+	}
+	if assignment.Allocation == nil {
+		allocation := 100
+		assignment.Allocation = &allocation
+	}
+	if *assignment.Allocation < 0 || *assignment.Allocation > 100 {
+		return "Allocation must be between 0 and 100"
+	}
+	return ""
+}
+
+func saveTeamMembership(db *gorm.DB, assignment *TeamAssignment) (*TeamMembership, error) {
+	membership := TeamMembership{
+		TeamMemberID: assignment.TeamMemberID,
+		TeamID:       assignment.TeamID,
+		Role:         assignment.Role,
+		Allocation:   *assignment.Allocation,
+		JoinedAt:     time.Now(),
+	}
+	if assignment.JoinedAt != nil {
+		membership.JoinedAt = *assignment.JoinedAt
+	}
+
+	updates := []string{"role", "allocation"}
+	if assignment.JoinedAt != nil {
+		updates = append(updates, "joined_at")
+	}
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "team_member_id"}, {Name: "team_id"}},
+		DoUpdates: clause.AssignmentColumns(updates),
+	}).Create(&membership).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Where("team_id = ? AND team_member_id = ?", membership.TeamID, membership.TeamMemberID).First(&membership).Error; err != nil {
+		return nil, err
+	}
+	return &membership, recordMembershipPeriod(db, &membership)
+}
+
+func recordMembershipPeriod(db *gorm.DB, membership *TeamMembership) error {
+	var period MembershipPeriod
+	err := db.Where("team_id = ? AND team_member_id = ? AND left_at IS NULL", membership.TeamID, membership.TeamMemberID).
+		First(&period).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err == nil && period.Role == membership.Role {
+		return db.Model(&period).Updates(map[string]interface{}{
+			"allocation": membership.Allocation,
+			"joined_at":  membership.JoinedAt,
+		}).Error
+	}
+
+	joinedAt := membership.JoinedAt
+	if err == nil {
+		now := time.Now()
+		if err := closeMembershipPeriods(db.Where("id = ?", period.ID), "role_changed", now); err != nil {
+			return err
+		}
+		joinedAt = now
+	}
+	return db.Create(&MembershipPeriod{
+		TeamMemberID: membership.TeamMemberID,
+		TeamID:       membership.TeamID,
+		Role:         membership.Role,
+		Allocation:   membership.Allocation,
+		JoinedAt:     joinedAt,
+	}).Error
+}
+
+func closeMembershipPeriods(scope *gorm.DB, reason string, now time.Time) error {
+	return scope.Model(&MembershipPeriod{}).Where("left_at IS NULL").
+		Updates(map[string]interface{}{"left_at": now, "reason": reason}).Error
+}
+
+func backfillMembershipPeriods(db *gorm.DB) error {
+	return db.Exec(`INSERT INTO membership_periods (team_member_id, team_id, role, allocation, joined_at, created_at)
+		SELECT mt.team_member_id, mt.team_id, mt.role, mt.allocation, COALESCE(mt.joined_at, CURRENT_TIMESTAMP), CURRENT_TIMESTAMP
+		FROM member_teams mt
+		WHERE NOT EXISTS (
+			SELECT 1 FROM membership_periods mp
+			WHERE mp.team_member_id = mt.team_member_id AND mp.team_id = mt.team_id AND mp.left_at IS NULL
+		)`).Error
+}
+
+func parseAsOf(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return day, err
+	}
+	return day.AddDate(0, 0, 1), nil
+}
+
+func teamCompositionAsOf(team *Team, asOf time.Time) error {
+	var periods []MembershipPeriod
+	err := DB.Where("team_id = ? AND joined_at < ? AND (left_at IS NULL OR left_at >= ?)", team.ID, asOf, asOf).
+		Order("joined_at, team_member_id").Find(&periods).Error
+	if err != nil {
+		return err
+	}
+
+	team.Members = []TeamMember{}
+	team.Memberships = []TeamMembership{}
+	if len(periods) == 0 {
+		return nil
+	}
+	memberIDs := make([]uint, 0, len(periods))
+	for _, period := range periods {
+		memberIDs = append(memberIDs, period.TeamMemberID)
+		team.Memberships = append(team.Memberships, TeamMembership{
+			TeamMemberID: period.TeamMemberID,
+			TeamID:       period.TeamID,
+			Role:         period.Role,
+			Allocation:   period.Allocation,
+			JoinedAt:     period.JoinedAt,
+		})
+	}
+	return DB.Where("id IN ?", memberIDs).Find(&team.Members).Error
+}
+
+func GetMemberMemberships(c *gin.Context) {
+	id := c.Param("id")
+	var member TeamMember
+	if err := DB.First(&member, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	var memberships []TeamMembership
+	if err := DB.Where("team_member_id = ?", member.ID).Order("joined_at, team_id").Find(&memberships).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, memberships)
+}
+
+func GetMemberTeamHistory(c *gin.Context) {
+	id := c.Param("id")
+	var member TeamMember
+	if err := DB.First(&member, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	var periods []MembershipPeriod
+	if err := DB.Preload("Team").Where("team_member_id = ?", member.ID).Order("joined_at, id").Find(&periods).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, periods)
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamMembershipRoles(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	lead := TeamMember{Name: "Lead", Email: "lead@example.com"}
+	dev := TeamMember{Name: "Dev", Email: "dev@example.com"}
+	DB.Create(&lead)
+	DB.Create(&dev)
+	team := Team{Name: "Platform"}
+	DB.Create(&team)
+
+	assign := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/api/v1/assign", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	teamID := strconv.Itoa(int(team.ID))
+
+	t.Run("Assign with role and allocation", func(t *testing.T) {
+		w := assign(`{"team_id": ` + teamID + `, "team_member_id": ` + strconv.Itoa(int(lead.ID)) + `, "role": "lead", "allocation": 50, "joined_at": "2026-01-05T00:00:00Z"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = assign(`{"team_id": ` + teamID + `, "team_member_id": ` + strconv.Itoa(int(dev.ID)) + `}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Membership TeamMembership `json:"membership"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "member", response.Membership.Role)
+		assert.Equal(t, 100, response.Membership.Allocation)
+	})
+
+	t.Run("Reject invalid role and allocation", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, assign(`{"team_id": `+teamID+`, "team_member_id": `+strconv.Itoa(int(dev.ID))+`, "role": "boss"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, assign(`{"team_id": `+teamID+`, "team_member_id": `+strconv.Itoa(int(dev.ID))+`, "allocation": 150}`).Code)
+	})
+
+	t.Run("Reassigning updates the role", func(t *testing.T) {
+		w := assign(`{"team_id": ` + teamID + `, "team_member_id": ` + strconv.Itoa(int(dev.ID)) + `, "role": "coach", "allocation": 20}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var count int64
+		DB.Model(&TeamMembership{}).Where("team_id = ?", team.ID).Count(&count)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("GetTeam returns roles", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/teams/"+teamID, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response Team
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response.Members, 2)
+		assert.Len(t, response.Memberships, 2)
+		assert.Equal(t, lead.ID, response.Memberships[0].TeamMemberID)
+		assert.Equal(t, "lead", response.Memberships[0].Role)
+		assert.Equal(t, 50, response.Memberships[0].Allocation)
+		assert.Equal(t, "coach", response.Memberships[1].Role)
+	})
+
+	t.Run("Team leads approve feedback about their members", func(t *testing.T) {
+		DB.Create(&ApprovalPolicy{Kind: "constructive"})
+		author := TeamMember{Name: "Author", Email: "author@example.com"}
+		DB.Create(&author)
+
+		body := `{"target_type": "member", "target_id": ` + strconv.Itoa(int(dev.ID)) + `, "author_id": ` + strconv.Itoa(int(author.ID)) + `, "kind": "constructive", "content": "Slow reviews"}`
+		req, _ := http.NewRequest("POST", "/api/v1/feedback", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var feedback Feedback
+		json.Unmarshal(w.Body.Bytes(), &feedback)
+		approval, err := loadFeedbackApproval(strconv.Itoa(int(feedback.ID)))
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{lead.ID}, approval.Approvers)
+	})
+}
+
+func TestMembershipHistory(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	alice := TeamMember{Name: "Alice", Email: "alice@example.com"}
+	bob := TeamMember{Name: "Bob", Email: "bob@example.com"}
+	DB.Create(&alice)
+	DB.Create(&bob)
+	team := Team{Name: "Payments"}
+	DB.Create(&team)
+	teamID := strconv.Itoa(int(team.ID))
+
+	performRequest(router, "POST", "/api/v1/assign", `{"team_id": `+teamID+`, "team_member_id": `+strconv.Itoa(int(alice.ID))+`, "joined_at": "2025-03-01T00:00:00Z"}`)
+	performRequest(router, "POST", "/api/v1/assign", `{"team_id": `+teamID+`, "team_member_id": `+strconv.Itoa(int(bob.ID))+`, "joined_at": "2025-09-01T00:00:00Z"}`)
+
+	t.Run("Removal closes the membership period", func(t *testing.T) {
+		w := performRequest(router, "DELETE", "/api/v1/remove-member/"+teamID+"/"+strconv.Itoa(int(alice.ID))+"?reason=moved+to+platform", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ := http.NewRequest("GET", "/api/v1/members/"+strconv.Itoa(int(alice.ID))+"/team-history", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var history []MembershipPeriod
+		json.Unmarshal(w.Body.Bytes(), &history)
+		assert.Len(t, history, 1)
+		assert.NotNil(t, history[0].LeftAt)
+		assert.Equal(t, "moved to platform", history[0].Reason)
+		assert.Equal(t, "Payments", history[0].Team.Name)
+	})
+
+	t.Run("Role changes start a new period", func(t *testing.T) {
+		performRequest(router, "POST", "/api/v1/assign", `{"team_id": `+teamID+`, "team_member_id": `+strconv.Itoa(int(bob.ID))+`, "role": "lead"}`)
+
+		var periods []MembershipPeriod
+		DB.Where("team_member_id = ?", bob.ID).Order("id").Find(&periods)
+		assert.Len(t, periods, 2)
+		assert.Equal(t, "member", periods[0].Role)
+		assert.Equal(t, "role_changed", periods[0].Reason)
+		assert.NotNil(t, periods[0].LeftAt)
+		assert.Equal(t, "lead", periods[1].Role)
+		assert.Nil(t, periods[1].LeftAt)
+	})
+
+	t.Run("Team composition as of a past date", func(t *testing.T) {
+		composition := func(asOf string) Team {
+			req, _ := http.NewRequest("GET", "/api/v1/teams/"+teamID+"?as_of="+asOf, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+			var response Team
+			json.Unmarshal(w.Body.Bytes(), &response)
+			return response
+		}
+
+		assert.Len(t, composition("2025-01-01").Members, 0)
+
+		spring := composition("2025-06-01")
+		assert.Len(t, spring.Members, 1)
+		assert.Equal(t, alice.ID, spring.Members[0].ID)
+
+		autumn := composition("2025-10-01")
+		assert.Len(t, autumn.Members, 2)
+		assert.Equal(t, "member", autumn.Memberships[1].Role)
+
+		current := composition(time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+		assert.Len(t, current.Members, 1)
+		assert.Equal(t, bob.ID, current.Members[0].ID)
+		assert.Equal(t, "lead", current.Memberships[0].Role)
+	})
+
+	t.Run("Reject invalid as_of", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/teams/"+teamID+"?as_of=yesterday", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Backfill opens periods for existing memberships", func(t *testing.T) {
+		carol := TeamMember{Name: "Carol", Email: "carol@example.com"}
+		DB.Create(&carol)
+		DB.Create(&TeamMembership{TeamMemberID: carol.ID, TeamID: team.ID, Role: "coach", Allocation: 20})
+
+		assert.NoError(t, backfillMembershipPeriods(DB))
+		assert.NoError(t, backfillMembershipPeriods(DB))
+
+		var periods []MembershipPeriod
+		DB.Where("team_member_id = ?", carol.ID).Find(&periods)
+		assert.Len(t, periods, 1)
+		assert.Equal(t, "coach", periods[0].Role)
+		assert.Equal(t, 20, periods[0].Allocation)
+	})
+}
@@ -6,19 +6,29 @@ import (
 
 type TeamMember struct {
 	ID      uint   `json:"id" gorm:"primaryKey"`
-	Name    string `json:"name" gorm:"not null"`
-	Picture string `json:"picture"`
-	Email   string `json:"email" gorm:"uniqueIndex;not null;size:255"`
+	Name    string `json:"name" gorm:"not null" binding:"required,notblank,max=255"`
+	Picture string `json:"picture" binding:"omitempty,imageurl,max=500"`
+	Email   string `json:"email" gorm:"uniqueIndex;not null;size:255" binding:"required,email,max=255"`
+	JobTitle  string     `json:"job_title" gorm:"size:255" binding:"max=255"`
+	Location  string     `json:"location" gorm:"size:255" binding:"max=255"`
+	Timezone  string     `json:"timezone" gorm:"size:64" binding:"omitempty,timezone,max=64"`
+	StartDate *time.Time `json:"start_date"`
+	Status    string     `json:"status" gorm:"not null;size:20;default:active;index" binding:"omitempty,oneof=active on-leave alumni"`
+	ManagerID *uint `json:"manager_id" gorm:"index"`
 	Teams   []Team `json:"teams" gorm:"many2many:member_teams;"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty" gorm:"-"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type Team struct {
 	ID       uint         `json:"id" gorm:"primaryKey"`
-	Name     string       `json:"name" gorm:"not null"`
-	Logo     string       `json:"logo"`
+	Name     string       `json:"name" gorm:"not null" binding:"required,notblank,max=255"`
+	Logo     string       `json:"logo" binding:"omitempty,imageurl,max=500"`
+	ParentID *uint        `json:"parent_id" gorm:"index"`
 	Members  []TeamMember `json:"members" gorm:"many2many:member_teams;"`
+	Memberships []TeamMembership `json:"memberships,omitempty" gorm:"-"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty" gorm:"-"`
 	CreatedAt time.Time   `json:"created_at"`
 	UpdatedAt time.Time   `json:"updated_at"`
 }
@@ -26,13 +36,560 @@ type Team struct {
 type Feedback struct {
 	ID           uint   `json:"id" gorm:"primaryKey"`
 	Content      string `json:"content" gorm:"not null"`
+	Kind         string `json:"kind" gorm:"not null;size:20;default:observation"`
+	Situation    string `json:"situation"`
+	Behavior     string `json:"behavior"`
+	Impact       string `json:"impact"`
+	TemplateID   *uint  `json:"template_id" gorm:"index"`
+	Rating       *int   `json:"rating"`
+	SentimentScore float64 `json:"sentiment_score" gorm:"not null;default:0"`
+	Status       string `json:"status" gorm:"not null;size:20;default:published;index"`
+	ReleaseAt    *time.Time `json:"release_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	ArchivedAt   *time.Time `json:"archived_at" gorm:"index"`
+	AcknowledgedBy *uint `json:"acknowledged_by"`
 	TargetType   string `json:"target_type" gorm:"not null;size:50"`
 	TargetID     uint   `json:"target_id" gorm:"not null"`
+	AuthorID     *uint  `json:"author_id" gorm:"index"`
+	FeedbackRequestID *uint `json:"feedback_request_id" gorm:"index"`
+	Anonymous    bool   `json:"anonymous" gorm:"not null;default:false"`
+	ContributorHash string `json:"-" gorm:"size:64"`
+	Competencies []CompetencyRating `json:"competencies" gorm:"foreignKey:FeedbackID"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
 type TeamAssignment struct {
-	TeamID       uint `json:"team_id"`
-	TeamMemberID uint `json:"team_member_id"`
-}
\ No newline at end of file
+	TeamID       uint       `json:"team_id"`
+	TeamMemberID uint       `json:"team_member_id"`
+	Role         string     `json:"role"`
+	Allocation   *int       `json:"allocation"`
+	JoinedAt     *time.Time `json:"joined_at"`
+}
+
+type TeamMembership struct {
+	TeamMemberID uint      `json:"team_member_id" gorm:"primaryKey"`
+	TeamID       uint      `json:"team_id" gorm:"primaryKey"`
+	Role         string    `json:"role" gorm:"not null;size:20;default:member"`
+	Allocation   int       `json:"allocation" gorm:"not null;default:100"`
+	JoinedAt     time.Time `json:"joined_at" gorm:"autoCreateTime"`
+}
+
+func (TeamMembership) TableName() string {
+	return "member_teams"
+}
+
+type CustomFieldDefinition struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	EntityType string    `json:"entity_type" gorm:"not null;size:20;uniqueIndex:idx_custom_field_key"`
+	Key        string    `json:"key" gorm:"column:field_key;not null;size:100;uniqueIndex:idx_custom_field_key"`
+	Label      string    `json:"label" gorm:"size:255"`
+	Type       string    `json:"type" gorm:"not null;size:20"`
+	Options    []string  `json:"options,omitempty" gorm:"serializer:json"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type CustomFieldValue struct {
+	ID           uint   `json:"id" gorm:"primaryKey"`
+	DefinitionID uint   `json:"definition_id" gorm:"not null;uniqueIndex:idx_custom_field_value"`
+	EntityID     uint   `json:"entity_id" gorm:"not null;uniqueIndex:idx_custom_field_value;index"`
+	Value        string `json:"value" gorm:"not null;size:255;index"`
+}
+
+type MembershipPeriod struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	TeamMemberID uint       `json:"team_member_id" gorm:"not null;index:idx_membership_periods_member"`
+	TeamID       uint       `json:"team_id" gorm:"not null;index:idx_membership_periods_team"`
+	Team         *Team      `json:"team,omitempty" gorm:"constraint:-"`
+	Role         string     `json:"role" gorm:"not null;size:20"`
+	Allocation   int        `json:"allocation" gorm:"not null"`
+	JoinedAt     time.Time  `json:"joined_at" gorm:"not null"`
+	LeftAt       *time.Time `json:"left_at"`
+	Reason       string     `json:"reason,omitempty" gorm:"size:255"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+type Goal struct {
+	ID          uint          `json:"id" gorm:"primaryKey"`
+	Title       string        `json:"title" gorm:"not null"`
+	Description string        `json:"description"`
+	OwnerType   string        `json:"owner_type" gorm:"not null;size:50;index:idx_goal_owner"`
+	OwnerID     uint          `json:"owner_id" gorm:"not null;index:idx_goal_owner"`
+	TargetDate  *time.Time    `json:"target_date"`
+	Progress    int           `json:"progress"`
+	Status      string        `json:"status" gorm:"not null;size:50;default:not_started"`
+	KeyResults  []KeyResult   `json:"key_results" gorm:"constraint:OnDelete:CASCADE;"`
+	CheckIns    []GoalCheckIn `json:"check_ins" gorm:"constraint:OnDelete:CASCADE;"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+type KeyResult struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	GoalID       uint      `json:"goal_id" gorm:"not null;index"`
+	Title        string    `json:"title" gorm:"not null"`
+	TargetValue  float64   `json:"target_value"`
+	CurrentValue float64   `json:"current_value"`
+	Unit         string    `json:"unit" gorm:"size:50"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type GoalCheckIn struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	GoalID    uint      `json:"goal_id" gorm:"not null;index"`
+	Progress  int       `json:"progress"`
+	Status    string    `json:"status" gorm:"size:50"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type KeyResultUpdate struct {
+	ID           uint    `json:"id"`
+	CurrentValue float64 `json:"current_value"`
+}
+
+type GoalCheckInRequest struct {
+	Progress   *int              `json:"progress"`
+	Status     string            `json:"status"`
+	Comment    string            `json:"comment"`
+	KeyResults []KeyResultUpdate `json:"key_results"`
+}
+
+type ActionItem struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	Title           string     `json:"title" gorm:"not null"`
+	Description     string     `json:"description"`
+	FeedbackID      *uint      `json:"feedback_id" gorm:"index"`
+	MeetingTitle    string     `json:"meeting_title"`
+	MeetingDate     *time.Time `json:"meeting_date"`
+	RetrospectiveID *uint      `json:"retrospective_id" gorm:"index"`
+	AssigneeID      uint       `json:"assignee_id" gorm:"not null;index"`
+	DueDate         *time.Time `json:"due_date" gorm:"index"`
+	Status          string     `json:"status" gorm:"not null;size:50;default:open"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+type ActionItemStatusChange struct {
+	Status string `json:"status"`
+}
+
+type ReviewCycle struct {
+	ID                 uint       `json:"id" gorm:"primaryKey"`
+	Name               string     `json:"name" gorm:"not null"`
+	PeriodStart        time.Time  `json:"period_start" gorm:"not null"`
+	PeriodEnd          time.Time  `json:"period_end" gorm:"not null"`
+	SelfReviewDeadline *time.Time `json:"self_review_deadline"`
+	PeerReviewDeadline *time.Time `json:"peer_review_deadline"`
+	Status             string     `json:"status" gorm:"not null;size:50;default:draft"`
+	Teams              []Team     `json:"teams" gorm:"many2many:review_cycle_teams;"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+type Review struct {
+	ID            uint               `json:"id" gorm:"primaryKey"`
+	ReviewCycleID uint               `json:"review_cycle_id" gorm:"not null;index"`
+	Type          string             `json:"type" gorm:"not null;size:50"`
+	ReviewerID    *uint              `json:"reviewer_id" gorm:"index"`
+	RevieweeID    uint               `json:"reviewee_id" gorm:"not null;index"`
+	Strengths     string             `json:"strengths"`
+	Improvements  string             `json:"improvements"`
+	Comments      string             `json:"comments"`
+	Status        string             `json:"status" gorm:"not null;size:50;default:draft"`
+	SubmittedAt   *time.Time         `json:"submitted_at"`
+	Competencies  []CompetencyRating `json:"competencies" gorm:"foreignKey:ReviewID"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+type ReviewCycleRequest struct {
+	Name               string     `json:"name"`
+	PeriodStart        time.Time  `json:"period_start"`
+	PeriodEnd          time.Time  `json:"period_end"`
+	SelfReviewDeadline *time.Time `json:"self_review_deadline"`
+	PeerReviewDeadline *time.Time `json:"peer_review_deadline"`
+	TeamIDs            []uint     `json:"team_ids"`
+}
+
+type ReviewCycleTransition struct {
+	Status string `json:"status"`
+}
+
+type ReviewSubmission struct {
+	Type         string             `json:"type"`
+	ReviewerID   uint               `json:"reviewer_id"`
+	RevieweeID   uint               `json:"reviewee_id"`
+	Strengths    string             `json:"strengths"`
+	Improvements string             `json:"improvements"`
+	Comments     string             `json:"comments"`
+	Submit       bool               `json:"submit"`
+	Competencies []CompetencyRating `json:"competencies"`
+}
+
+type FeedbackRequest struct {
+	ID             uint                       `json:"id" gorm:"primaryKey"`
+	RequesterID    *uint                      `json:"requester_id" gorm:"index"`
+	TargetMemberID uint                       `json:"target_member_id" gorm:"not null;index"`
+	Message        string                     `json:"message"`
+	DueDate        *time.Time                 `json:"due_date"`
+	Status         string                     `json:"status" gorm:"not null;size:50;default:open"`
+	Recipients     []FeedbackRequestRecipient `json:"recipients"`
+	Completion     *FeedbackRequestCompletion `json:"completion,omitempty" gorm:"-"`
+	CreatedAt      time.Time                  `json:"created_at"`
+	UpdatedAt      time.Time                  `json:"updated_at"`
+}
+
+type FeedbackRequestCompletion struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Declined  int `json:"declined"`
+	Pending   int `json:"pending"`
+	Percent   int `json:"percent"`
+}
+
+type FeedbackRequestRecipient struct {
+	ID                uint       `json:"id" gorm:"primaryKey"`
+	FeedbackRequestID uint       `json:"feedback_request_id" gorm:"not null;uniqueIndex:idx_request_recipient"`
+	RecipientID       uint       `json:"recipient_id" gorm:"not null;uniqueIndex:idx_request_recipient"`
+	Status            string     `json:"status" gorm:"not null;size:50;default:pending"`
+	DeclineReason     string     `json:"decline_reason"`
+	FeedbackID        *uint      `json:"feedback_id"`
+	ReminderCount     int        `json:"reminder_count"`
+	LastRemindedAt    *time.Time `json:"last_reminded_at"`
+	RespondedAt       *time.Time `json:"responded_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type Notification struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	MemberID  uint       `json:"member_id" gorm:"not null;index"`
+	Kind      string     `json:"kind" gorm:"not null;size:50"`
+	Message   string     `json:"message" gorm:"not null"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type FeedbackRequestCreate struct {
+	RequesterID    uint       `json:"requester_id"`
+	TargetMemberID uint       `json:"target_member_id"`
+	RecipientIDs   []uint     `json:"recipient_ids"`
+	Message        string     `json:"message"`
+	DueDate        *time.Time `json:"due_date"`
+}
+
+type FeedbackRequestResponse struct {
+	RecipientID uint   `json:"recipient_id"`
+	Content     string `json:"content"`
+}
+
+type FeedbackRequestDecline struct {
+	RecipientID uint   `json:"recipient_id"`
+	Reason      string `json:"reason"`
+}
+
+type SurveyTemplate struct {
+	ID          uint             `json:"id" gorm:"primaryKey"`
+	Name        string           `json:"name" gorm:"not null"`
+	Description string           `json:"description"`
+	Questions   []SurveyQuestion `json:"questions" gorm:"constraint:OnDelete:CASCADE;"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+type SurveyQuestion struct {
+	ID               uint   `json:"id" gorm:"primaryKey"`
+	SurveyTemplateID uint   `json:"survey_template_id" gorm:"not null;index"`
+	Prompt           string `json:"prompt" gorm:"not null"`
+	AnswerType       string `json:"answer_type" gorm:"not null;size:50"`
+	ScaleMin         int    `json:"scale_min"`
+	ScaleMax         int    `json:"scale_max"`
+	Position         int    `json:"position"`
+}
+
+type SurveyRun struct {
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	SurveyTemplateID uint       `json:"survey_template_id" gorm:"not null;index"`
+	TeamID           uint       `json:"team_id" gorm:"not null;index"`
+	ClosesAt         *time.Time `json:"closes_at"`
+	Status           string     `json:"status" gorm:"not null;size:50;default:open"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type SurveyResponse struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	SurveyRunID uint           `json:"survey_run_id" gorm:"not null;uniqueIndex:idx_survey_response_member"`
+	MemberID    *uint          `json:"member_id" gorm:"uniqueIndex:idx_survey_response_member"`
+	Answers     []SurveyAnswer `json:"answers" gorm:"constraint:OnDelete:CASCADE;"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type SurveyAnswer struct {
+	ID               uint   `json:"id" gorm:"primaryKey"`
+	SurveyResponseID uint   `json:"survey_response_id" gorm:"not null;index"`
+	SurveyQuestionID uint   `json:"question_id" gorm:"not null;index"`
+	Value            *int   `json:"value"`
+	Light            string `json:"light" gorm:"size:10"`
+	Text             string `json:"text"`
+}
+
+type SurveyQuestionResult struct {
+	QuestionID  uint           `json:"question_id"`
+	Prompt      string         `json:"prompt"`
+	AnswerType  string         `json:"answer_type"`
+	Responses   int            `json:"responses"`
+	Score       *float64       `json:"score"`
+	LightCounts map[string]int `json:"light_counts,omitempty"`
+	Texts       []string       `json:"texts,omitempty"`
+	Trend       string         `json:"trend,omitempty"`
+}
+
+type Retrospective struct {
+	ID             uint          `json:"id" gorm:"primaryKey"`
+	TeamID         uint          `json:"team_id" gorm:"not null;index"`
+	Title          string        `json:"title" gorm:"not null"`
+	Status         string        `json:"status" gorm:"not null;size:50;default:open"`
+	VotesPerMember int           `json:"votes_per_member" gorm:"not null;default:3"`
+	FeedbackID     *uint         `json:"feedback_id"`
+	ClosedAt       *time.Time    `json:"closed_at"`
+	Columns        []RetroColumn `json:"columns" gorm:"constraint:OnDelete:CASCADE;"`
+	Cards          []RetroCard   `json:"cards" gorm:"constraint:OnDelete:CASCADE;"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+type RetroColumn struct {
+	ID              uint   `json:"id" gorm:"primaryKey"`
+	RetrospectiveID uint   `json:"retrospective_id" gorm:"not null;index"`
+	Name            string `json:"name" gorm:"not null"`
+	Position        int    `json:"position"`
+}
+
+type RetroCard struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	RetrospectiveID uint      `json:"retrospective_id" gorm:"not null;index"`
+	RetroColumnID   uint      `json:"column_id" gorm:"not null;index"`
+	AuthorID        *uint     `json:"author_id"`
+	Content         string    `json:"content" gorm:"not null"`
+	GroupID         *uint     `json:"group_id" gorm:"index"`
+	Votes           int       `json:"votes" gorm:"-"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type RetroVote struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	RetroCardID uint      `json:"retro_card_id" gorm:"not null;uniqueIndex:idx_retro_vote"`
+	MemberID    uint      `json:"member_id" gorm:"not null;uniqueIndex:idx_retro_vote"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type RetrospectiveCreate struct {
+	TeamID         uint     `json:"team_id"`
+	Title          string   `json:"title"`
+	Columns        []string `json:"columns"`
+	VotesPerMember int      `json:"votes_per_member"`
+}
+
+type RetroVoteRequest struct {
+	MemberID uint `json:"member_id"`
+}
+
+type RetroCardGroupRequest struct {
+	GroupID *uint `json:"group_id"`
+}
+
+type RetroActionItemRequest struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	AssigneeID  uint       `json:"assignee_id"`
+	DueDate     *time.Time `json:"due_date"`
+}
+
+type Competency struct {
+	ID          uint              `json:"id" gorm:"primaryKey"`
+	Name        string            `json:"name" gorm:"not null;uniqueIndex;size:255"`
+	Description string            `json:"description"`
+	Levels      []CompetencyLevel `json:"levels" gorm:"constraint:OnDelete:CASCADE;"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+type CompetencyLevel struct {
+	ID           uint   `json:"id" gorm:"primaryKey"`
+	CompetencyID uint   `json:"competency_id" gorm:"not null;index"`
+	Level        int    `json:"level" gorm:"not null"`
+	Name         string `json:"name" gorm:"not null"`
+	Description  string `json:"description"`
+}
+
+type CompetencyRating struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	CompetencyID uint      `json:"competency_id" gorm:"not null;index"`
+	MemberID     uint      `json:"member_id" gorm:"not null;index"`
+	Rating       int       `json:"rating" gorm:"not null"`
+	FeedbackID   *uint     `json:"feedback_id" gorm:"index"`
+	ReviewID     *uint     `json:"review_id" gorm:"index"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type CompetencyExpectation struct {
+	ID            uint `json:"id" gorm:"primaryKey"`
+	MemberID      uint `json:"member_id" gorm:"not null;uniqueIndex:idx_member_competency"`
+	CompetencyID  uint `json:"competency_id" gorm:"not null;uniqueIndex:idx_member_competency"`
+	ExpectedLevel int  `json:"expected_level" gorm:"not null"`
+}
+
+type CompetencyProfileEntry struct {
+	CompetencyID  uint     `json:"competency_id"`
+	Name          string   `json:"name"`
+	ExpectedLevel *int     `json:"expected_level"`
+	AverageRating *float64 `json:"average_rating"`
+	LatestRating  *int     `json:"latest_rating"`
+	Ratings       int      `json:"ratings"`
+	Gap           *float64 `json:"gap"`
+}
+
+type Skill struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex;size:100"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MemberSkill struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	MemberID   uint      `json:"member_id" gorm:"not null;uniqueIndex:idx_member_skill"`
+	SkillID    uint      `json:"skill_id" gorm:"not null;uniqueIndex:idx_member_skill"`
+	Skill      Skill     `json:"skill"`
+	SelfLevel  *int      `json:"self_level"`
+	CoachLevel *int      `json:"coach_level"`
+	Level      int       `json:"level" gorm:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type MemberSkillRequest struct {
+	SkillID    uint   `json:"skill_id"`
+	SkillName  string `json:"skill"`
+	SelfLevel  *int   `json:"self_level"`
+	CoachLevel *int   `json:"coach_level"`
+}
+
+type FeedbackTemplate struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	Name           string    `json:"name" gorm:"not null;uniqueIndex;size:255"`
+	Kind           string    `json:"kind" gorm:"not null;size:20"`
+	Description    string    `json:"description"`
+	RequiredFields []string  `json:"required_fields" gorm:"serializer:json"`
+	Active         bool      `json:"active" gorm:"not null;default:true"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type FeedbackReply struct {
+	ID         uint            `json:"id" gorm:"primaryKey"`
+	FeedbackID uint            `json:"feedback_id" gorm:"not null;index"`
+	ParentID   *uint           `json:"parent_id" gorm:"index"`
+	AuthorID   uint            `json:"author_id" gorm:"not null"`
+	Content    string          `json:"content" gorm:"not null"`
+	Replies    []FeedbackReply `json:"replies" gorm:"-"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+type FeedbackAcknowledgement struct {
+	MemberID uint `json:"member_id"`
+}
+
+type FeedbackPublishRequest struct {
+	AuthorID  uint       `json:"author_id"`
+	ReleaseAt *time.Time `json:"release_at"`
+}
+
+type ApprovalPolicy struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	Kind       string    `json:"kind" gorm:"not null;uniqueIndex;size:20"`
+	ApproverID *uint     `json:"approver_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type FeedbackApproval struct {
+	ID               uint                    `json:"id" gorm:"primaryKey"`
+	FeedbackID       uint                    `json:"feedback_id" gorm:"not null;uniqueIndex"`
+	Status           string                  `json:"status" gorm:"not null;size:50;default:pending"`
+	RequestedStatus  string                  `json:"requested_status" gorm:"not null;size:20"`
+	Approvers        []uint                  `json:"approvers" gorm:"serializer:json"`
+	DecidedBy        *uint                   `json:"decided_by"`
+	DecidedAt        *time.Time              `json:"decided_at"`
+	Comment          string                  `json:"comment"`
+	SuggestedContent string                  `json:"suggested_content"`
+	Events           []FeedbackApprovalEvent `json:"events" gorm:"foreignKey:FeedbackApprovalID"`
+	CreatedAt        time.Time               `json:"created_at"`
+	UpdatedAt        time.Time               `json:"updated_at"`
+}
+
+type FeedbackApprovalEvent struct {
+	ID                 uint      `json:"id" gorm:"primaryKey"`
+	FeedbackApprovalID uint      `json:"feedback_approval_id" gorm:"not null;index"`
+	FromStatus         string    `json:"from_status" gorm:"size:50"`
+	ToStatus           string    `json:"to_status" gorm:"not null;size:50"`
+	ActorID            *uint     `json:"actor_id"`
+	Comment            string    `json:"comment"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type FeedbackApprovalDecision struct {
+	ApproverID       uint   `json:"approver_id"`
+	Decision         string `json:"decision"`
+	Comment          string `json:"comment"`
+	SuggestedContent string `json:"suggested_content"`
+}
+
+type FeedbackResubmission struct {
+	AuthorID uint   `json:"author_id"`
+	Content  string `json:"content"`
+}
+
+type Project struct {
+	ID          uint         `json:"id" gorm:"primaryKey"`
+	Name        string       `json:"name" gorm:"not null;size:255"`
+	Description string       `json:"description"`
+	StartDate   *time.Time   `json:"start_date"`
+	EndDate     *time.Time   `json:"end_date"`
+	Members     []TeamMember `json:"members" gorm:"many2many:project_members;"`
+	Teams       []Team       `json:"teams" gorm:"many2many:project_teams;"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+type ProjectInput struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	StartDate   *time.Time `json:"start_date"`
+	EndDate     *time.Time `json:"end_date"`
+	MemberIDs   []uint     `json:"member_ids"`
+	TeamIDs     []uint     `json:"team_ids"`
+}
+
+type TeamNode struct {
+	ID                uint       `json:"id"`
+	Name              string     `json:"name"`
+	Logo              string     `json:"logo"`
+	ParentID          *uint      `json:"parent_id"`
+	MemberCount       int        `json:"member_count"`
+	RollupMemberCount int        `json:"rollup_member_count"`
+	Children          []TeamNode `json:"children"`
+}
+
+type OrgNode struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	Picture     string    `json:"picture"`
+	ManagerID   *uint     `json:"manager_id"`
+	ReportCount int       `json:"report_count"`
+	Reports     []OrgNode `json:"reports"`
+}
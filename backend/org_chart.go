@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type orgChart struct {
+	members map[uint]TeamMember
+	reports map[uint][]uint
+	roots   []uint
+}
+
+func loadOrgChart() (*orgChart, error) {
+	var members []TeamMember
+	if err := DB.Order("name, id").Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	chart := &orgChart{members: map[uint]TeamMember{}, reports: map[uint][]uint{}}
+	for _, member := range members {
+		chart.members[member.ID] = member
+	}
+	for _, member := range members {
+		if member.ManagerID != nil {
+			if _, ok := chart.members[*member.ManagerID]; ok {
+				chart.reports[*member.ManagerID] = append(chart.reports[*member.ManagerID], member.ID)
+				continue
+			}
+		}
+		chart.roots = append(chart.roots, member.ID)
+	}
+	return chart, nil
+}
+
+func (chart *orgChart) managerChain(memberID uint) []uint {
+	chain := []uint{}
+	seen := map[uint]bool{memberID: true}
+	for managerID := chart.members[memberID].ManagerID; managerID != nil && !seen[*managerID]; {
+		manager, ok := chart.members[*managerID]
+		if !ok {
+			break
+		}
+		seen[manager.ID] = true
+		chain = append(chain, manager.ID)
+		managerID = manager.ManagerID
+	}
+	return chain
+}
+
+func validateManager(memberID uint, managerID *uint) (int, string) {
+	if managerID == nil {
+		return 0, ""
+	}
+	if *managerID == memberID {
+		return http.StatusBadRequest, "A member cannot be their own manager"
+	}
+
+	chart, err := loadOrgChart()
+	if err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	if _, ok := chart.members[*managerID]; !ok {
+		return http.StatusBadRequest, "Manager not found"
+	}
+	if memberID == 0 {
+		return 0, ""
+	}
+	for _, id := range chart.managerChain(*managerID) {
+		if id == memberID {
+			return http.StatusConflict, "A member cannot report to one of their own reports"
+		}
+	}
+	return 0, ""
+}
+
+func memberManagerID(memberID uint) *uint {
+	var member TeamMember
+	if err := DB.Select("id, manager_id").First(&member, memberID).Error; err != nil {
+		return nil
+	}
+	return member.ManagerID
+}
+
+func (chart *orgChart) buildNode(memberID uint, seen map[uint]bool) OrgNode {
+	seen[memberID] = true
+	member := chart.members[memberID]
+	node := OrgNode{ID: member.ID, Name: member.Name, Email: member.Email, Picture: member.Picture, ManagerID: member.ManagerID, Reports: []OrgNode{}}
+	for _, reportID := range chart.reports[memberID] {
+		if seen[reportID] {
+			continue
+		}
+		report := chart.buildNode(reportID, seen)
+		node.ReportCount += 1 + report.ReportCount
+		node.Reports = append(node.Reports, report)
+	}
+	return node
+}
+
+func walkOrgNodes(nodes []OrgNode, visit func(node OrgNode)) {
+	for _, node := range nodes {
+		visit(node)
+		walkOrgNodes(node.Reports, visit)
+	}
+}
+
+func orgChartDOT(nodes []OrgNode) string {
+	var b strings.Builder
+	b.WriteString("digraph orgchart {\n  rankdir=TB;\n  node [shape=box];\n")
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	walkOrgNodes(nodes, func(node OrgNode) {
+		fmt.Fprintf(&b, "  m%d [label=\"%s\"];\n", node.ID, escape.Replace(node.Name))
+	})
+	walkOrgNodes(nodes, func(node OrgNode) {
+		for _, report := range node.Reports {
+			fmt.Fprintf(&b, "  m%d -> m%d;\n", node.ID, report.ID)
+		}
+	})
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func orgChartMermaid(nodes []OrgNode) string {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+	walkOrgNodes(nodes, func(node OrgNode) {
+		fmt.Fprintf(&b, "  m%d[\"%s\"]\n", node.ID, strings.ReplaceAll(node.Name, `"`, "#quot;"))
+	})
+	walkOrgNodes(nodes, func(node OrgNode) {
+		for _, report := range node.Reports {
+			fmt.Fprintf(&b, "  m%d --> m%d\n", node.ID, report.ID)
+		}
+	})
+	return b.String()
+}
+
+func GetOrgChart(c *gin.Context) {
+	chart, err := loadOrgChart()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	roots := chart.roots
+	if value := c.Query("root_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "root_id must be a positive number"})
+			return
+		}
+		if _, ok := chart.members[uint(id)]; !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+			return
+		}
+		roots = []uint{uint(id)}
+	}
+
+	nodes := []OrgNode{}
+	seen := map[uint]bool{}
+	for _, rootID := range roots {
+		nodes = append(nodes, chart.buildNode(rootID, seen))
+	}
+
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		c.JSON(http.StatusOK, nodes)
+	case "dot":
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(orgChartDOT(nodes)))
+	case "mermaid":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(orgChartMermaid(nodes)))
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Format must be 'json', 'dot' or 'mermaid'"})
+	}
+}
+
+func GetDirectReports(c *gin.Context) {
+	var member TeamMember
+	if err := DB.First(&member, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	var reports []TeamMember
+	if err := DB.Where("manager_id = ?", member.ID).Order("name, id").Find(&reports).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, reports)
+}
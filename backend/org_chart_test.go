@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrgChart(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	create := func(body string) TeamMember {
+		w := performRequest(router, "POST", "/api/v1/members", body)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var member TeamMember
+		json.Unmarshal(w.Body.Bytes(), &member)
+		return member
+	}
+
+	ceo := create(`{"name": "Ada \"The Boss\"", "email": "ada@example.com"}`)
+	cto := create(`{"name": "Brian", "email": "brian@example.com", "manager_id": ` + strconv.Itoa(int(ceo.ID)) + `}`)
+	dev := create(`{"name": "Cleo", "email": "cleo@example.com", "manager_id": ` + strconv.Itoa(int(cto.ID)) + `}`)
+
+	t.Run("Reject unknown manager", func(t *testing.T) {
+		w := performRequest(router, "POST", "/api/v1/members", `{"name": "Dan", "email": "dan@example.com", "manager_id": 9999}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Reject reporting cycles", func(t *testing.T) {
+		w := performRequest(router, "PUT", "/api/v1/members/"+strconv.Itoa(int(ceo.ID)), `{"name": "Ada", "email": "ada@example.com", "manager_id": `+strconv.Itoa(int(dev.ID))+`}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Reject invalid managers on update", func(t *testing.T) {
+		w := performRequest(router, "PUT", "/api/v1/members/"+strconv.Itoa(int(cto.ID)), `{"name": "Brian", "email": "brian@example.com", "manager_id": `+strconv.Itoa(int(cto.ID))+`}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = performRequest(router, "PUT", "/api/v1/members/"+strconv.Itoa(int(cto.ID)), `{"name": "Brian", "email": "brian@example.com", "manager_id": 9999}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Org chart tree", func(t *testing.T) {
+		w := performRequest(router, "GET", "/api/v1/orgchart", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var nodes []OrgNode
+		json.Unmarshal(w.Body.Bytes(), &nodes)
+		assert.Len(t, nodes, 1)
+		assert.Equal(t, ceo.ID, nodes[0].ID)
+		assert.Equal(t, 2, nodes[0].ReportCount)
+		assert.Equal(t, cto.ID, nodes[0].Reports[0].ID)
+		assert.Equal(t, dev.ID, nodes[0].Reports[0].Reports[0].ID)
+
+		w = performRequest(router, "GET", "/api/v1/orgchart?root_id="+strconv.Itoa(int(cto.ID)), "")
+		json.Unmarshal(w.Body.Bytes(), &nodes)
+		assert.Len(t, nodes, 1)
+		assert.Equal(t, 1, nodes[0].ReportCount)
+	})
+
+	t.Run("Export to DOT and Mermaid", func(t *testing.T) {
+		w := performRequest(router, "GET", "/api/v1/orgchart?format=dot", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, strings.HasPrefix(w.Body.String(), "digraph orgchart {"))
+		assert.Contains(t, w.Body.String(), `[label="Ada \"The Boss\""]`)
+		assert.Contains(t, w.Body.String(), "m"+strconv.Itoa(int(ceo.ID))+" -> m"+strconv.Itoa(int(cto.ID))+";")
+
+		w = performRequest(router, "GET", "/api/v1/orgchart?format=mermaid", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, strings.HasPrefix(w.Body.String(), "flowchart TD"))
+		assert.Contains(t, w.Body.String(), `["Ada #quot;The Boss#quot;"]`)
+		assert.Contains(t, w.Body.String(), "m"+strconv.Itoa(int(cto.ID))+" --> m"+strconv.Itoa(int(dev.ID)))
+
+		w = performRequest(router, "GET", "/api/v1/orgchart?format=svg", "")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Managers approve feedback about their reports", func(t *testing.T) {
+		DB.Create(&ApprovalPolicy{Kind: "constructive"})
+
+		body := `{"target_type": "member", "target_id": ` + strconv.Itoa(int(dev.ID)) + `, "author_id": ` + strconv.Itoa(int(ceo.ID)) + `, "kind": "constructive", "content": "Missed the deadline"}`
+		w := performRequest(router, "POST", "/api/v1/feedback", body)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var feedback Feedback
+		json.Unmarshal(w.Body.Bytes(), &feedback)
+		approval, err := loadFeedbackApproval(strconv.Itoa(int(feedback.ID)))
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{cto.ID}, approval.Approvers)
+	})
+
+	t.Run("Deleting a manager moves reports up", func(t *testing.T) {
+		w := performRequest(router, "DELETE", "/api/v1/members/"+strconv.Itoa(int(cto.ID)), "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = performRequest(router, "GET", "/api/v1/members/"+strconv.Itoa(int(ceo.ID))+"/reports", "")
+		var reports []TeamMember
+		json.Unmarshal(w.Body.Bytes(), &reports)
+		assert.Len(t, reports, 1)
+		assert.Equal(t, dev.ID, reports[0].ID)
+	})
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const defaultMemberStatus = "active"
+
+var customFieldEntityTypes = map[string]bool{
+	"member": true,
+	"team":   true,
+}
+
+var customFieldTypes = map[string]bool{
+	"string": true,
+	"number": true,
+	"date":   true,
+	"enum":   true,
+}
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+const maxCustomFieldLength = 255
+
+type customFieldChange struct {
+	DefinitionID uint
+	Value        *string
+}
+
+func applyMemberDefaults(member *TeamMember) {
+	if member.Status == "" {
+		member.Status = defaultMemberStatus
+	}
+}
+
+func customFieldDefinitions(entityType string) (map[string]CustomFieldDefinition, error) {
+	var definitions []CustomFieldDefinition
+	if err := DB.Where("entity_type = ?", entityType).Find(&definitions).Error; err != nil {
+		return nil, err
+	}
+	byKey := map[string]CustomFieldDefinition{}
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+	return byKey, nil
+}
+
+func normalizeCustomFieldValue(definition CustomFieldDefinition, raw interface{}) (string, string) {
+	switch definition.Type {
+	case "number":
+		switch value := raw.(type) {
+		case float64:
+			return strconv.FormatFloat(value, 'f', -1, 64), ""
+		case string:
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				return strconv.FormatFloat(number, 'f', -1, 64), ""
+			}
+		}
+		return "", "must be a number"
+	case "date":
+		if value, ok := raw.(string); ok {
+			if date, err := time.Parse("2006-01-02", value); err == nil {
+				return date.Format("2006-01-02"), ""
+			}
+		}
+		return "", "must be a date (YYYY-MM-DD)"
+	case "enum":
+		if value, ok := raw.(string); ok {
+			for _, option := range definition.Options {
+				if option == value {
+					return value, ""
+				}
+			}
+		}
+		return "", "must be one of: " + strings.Join(definition.Options, ", ")
+	default:
+		value, ok := raw.(string)
+		if !ok {
+			return "", "must be a string"
+		}
+		if utf8.RuneCountInString(value) > maxCustomFieldLength {
+			return "", "must be at most " + strconv.Itoa(maxCustomFieldLength) + " characters"
+		}
+		return value, ""
+	}
+}
+
+func decodeCustomFieldValue(definition CustomFieldDefinition, value string) interface{} {
+	if definition.Type == "number" {
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	}
+	return value
+}
+
+func validateCustomFields(entityType string, values map[string]interface{}) ([]customFieldChange, map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil, nil
+	}
+	definitions, err := customFieldDefinitions(entityType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes := []customFieldChange{}
+	fields := map[string]string{}
+	for key, raw := range values {
+		definition, ok := definitions[key]
+		if !ok {
+			fields["custom_fields."+key] = "is not a defined custom field"
+			continue
+		}
+		if raw == nil {
+			changes = append(changes, customFieldChange{DefinitionID: definition.ID})
+			continue
+		}
+		value, msg := normalizeCustomFieldValue(definition, raw)
+		if msg != "" {
+			fields["custom_fields."+key] = msg
+			continue
+		}
+		changes = append(changes, customFieldChange{DefinitionID: definition.ID, Value: &value})
+	}
+	if len(fields) > 0 {
+		return nil, fields, nil
+	}
+	return changes, nil, nil
+}
+
+func saveCustomFields(tx *gorm.DB, entityID uint, changes []customFieldChange) error {
+	for _, change := range changes {
+		if err := tx.Where("definition_id = ? AND entity_id = ?", change.DefinitionID, entityID).Delete(&CustomFieldValue{}).Error; err != nil {
+			return err
+		}
+		if change.Value == nil {
+			continue
+		}
+		if err := tx.Create(&CustomFieldValue{DefinitionID: change.DefinitionID, EntityID: entityID, Value: *change.Value}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadCustomFields(entityType string, entityIDs []uint) (map[uint]map[string]interface{}, error) {
+	fields := map[uint]map[string]interface{}{}
+	if len(entityIDs) == 0 {
+		return fields, nil
+	}
+
+	var definitions []CustomFieldDefinition
+	if err := DB.Where("entity_type = ?", entityType).Find(&definitions).Error; err != nil {
+		return nil, err
+	}
+	byID := map[uint]CustomFieldDefinition{}
+	definitionIDs := []uint{}
+	for _, definition := range definitions {
+		byID[definition.ID] = definition
+		definitionIDs = append(definitionIDs, definition.ID)
+	}
+	if len(definitionIDs) == 0 {
+		return fields, nil
+	}
+
+	var values []CustomFieldValue
+	if err := DB.Where("definition_id IN ? AND entity_id IN ?", definitionIDs, entityIDs).Find(&values).Error; err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		definition := byID[value.DefinitionID]
+		if fields[value.EntityID] == nil {
+			fields[value.EntityID] = map[string]interface{}{}
+		}
+		fields[value.EntityID][definition.Key] = decodeCustomFieldValue(definition, value.Value)
+	}
+	return fields, nil
+}
+
+func customFieldFilters(c *gin.Context, entityType string, query *gorm.DB) (*gorm.DB, string) {
+	var definitions map[string]CustomFieldDefinition
+	for param, values := range c.Request.URL.Query() {
+		if !strings.HasPrefix(param, "cf.") {
+			continue
+		}
+		if definitions == nil {
+			var err error
+			if definitions, err = customFieldDefinitions(entityType); err != nil {
+				return nil, err.Error()
+			}
+		}
+		key := strings.TrimPrefix(param, "cf.")
+		definition, ok := definitions[key]
+		if !ok {
+			return nil, "Unknown custom field: " + key
+		}
+		value, msg := normalizeCustomFieldValue(definition, values[0])
+		if msg != "" {
+			return nil, msg
+		}
+		query = query.Where("id IN (?)", DB.Model(&CustomFieldValue{}).Select("entity_id").
+			Where("definition_id = ? AND value = ?", definition.ID, value))
+	}
+	return query, ""
+}
+
+func CreateCustomField(c *gin.Context) {
+	var definition CustomFieldDefinition
+	if err := c.ShouldBindJSON(&definition); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !customFieldEntityTypes[definition.EntityType] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Entity type must be 'member' or 'team'"})
+		return
+	}
+	if !customFieldKeyPattern.MatchString(definition.Key) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Key must start with a letter and contain only lowercase letters, digits and underscores"})
+		return
+	}
+	if !customFieldTypes[definition.Type] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Type must be 'string', 'number', 'date' or 'enum'"})
+		return
+	}
+	if definition.Type == "enum" && len(definition.Options) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Enum fields need at least one option"})
+		return
+	}
+	if definition.Type != "enum" {
+		definition.Options = nil
+	}
+	if definition.Label == "" {
+		definition.Label = definition.Key
+	}
+
+	var count int64
+	DB.Model(&CustomFieldDefinition{}).Where("entity_type = ? AND field_key = ?", definition.EntityType, definition.Key).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Custom field already exists"})
+		return
+	}
+
+	if err := DB.Create(&definition).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, definition)
+}
+
+func GetCustomFields(c *gin.Context) {
+	query := DB.Order("entity_type, field_key")
+	if entityType := c.Query("entity_type"); entityType != "" {
+		query = query.Where("entity_type = ?", entityType)
+	}
+
+	var definitions []CustomFieldDefinition
+	if err := query.Find(&definitions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, definitions)
+}
+
+func DeleteCustomField(c *gin.Context) {
+	var definition CustomFieldDefinition
+	if err := DB.First(&definition, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Custom field not found"})
+		return
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("definition_id = ?", definition.ID).Delete(&CustomFieldValue{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&definition).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Custom field deleted"})
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberProfiles(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	t.Run("Create member with profile", func(t *testing.T) {
+		w := performRequest(router, "POST", "/api/v1/members", `{"name": "Ines", "email": "ines@example.com", "job_title": "Staff Engineer", "location": "Lisbon", "timezone": "Europe/Lisbon", "start_date": "2024-02-01T00:00:00Z"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var member TeamMember
+		json.Unmarshal(w.Body.Bytes(), &member)
+		assert.Equal(t, "Staff Engineer", member.JobTitle)
+		assert.Equal(t, "Europe/Lisbon", member.Timezone)
+		assert.Equal(t, "active", member.Status)
+		assert.NotNil(t, member.StartDate)
+	})
+
+	t.Run("Reject invalid status and timezone", func(t *testing.T) {
+		w := performRequest(router, "POST", "/api/v1/members", `{"name": "Jon", "email": "jon@example.com", "status": "retired"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		w = performRequest(router, "POST", "/api/v1/members", `{"name": "Jon", "email": "jon@example.com", "timezone": "Mars/Olympus"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("Update status and filter by it", func(t *testing.T) {
+		var member TeamMember
+		DB.Where("email = ?", "ines@example.com").First(&member)
+
+		w := performRequest(router, "PUT", "/api/v1/members/"+strconv.Itoa(int(member.ID)), `{"name": "Ines", "email": "ines@example.com", "status": "on-leave"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = performRequest(router, "GET", "/api/v1/members?status=on-leave", "")
+		var members []TeamMember
+		json.Unmarshal(w.Body.Bytes(), &members)
+		assert.Len(t, members, 1)
+		assert.Equal(t, member.ID, members[0].ID)
+	})
+}
+
+func TestCustomFields(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	t.Run("Define custom fields", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, performRequest(router, "POST", "/api/v1/custom-fields", `{"entity_type": "member", "key": "level", "type": "enum", "options": ["junior", "senior"]}`).Code)
+		assert.Equal(t, http.StatusCreated, performRequest(router, "POST", "/api/v1/custom-fields", `{"entity_type": "member", "key": "desk", "type": "number"}`).Code)
+		assert.Equal(t, http.StatusCreated, performRequest(router, "POST", "/api/v1/custom-fields", `{"entity_type": "member", "key": "badge_expiry", "type": "date"}`).Code)
+		assert.Equal(t, http.StatusCreated, performRequest(router, "POST", "/api/v1/custom-fields", `{"entity_type": "team", "key": "cost_center", "type": "string"}`).Code)
+	})
+
+	t.Run("Reject invalid definitions", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, performRequest(router, "POST", "/api/v1/custom-fields", `{"entity_type": "project", "key": "code", "type": "string"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, performRequest(router, "POST", "/api/v1/custom-fields", `{"entity_type": "member", "key": "Bad Key", "type": "string"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, performRequest(router, "POST", "/api/v1/custom-fields", `{"entity_type": "member", "key": "shirt", "type": "enum"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, performRequest(router, "POST", "/api/v1/custom-fields", `{"entity_type": "member", "key": "shirt", "type": "color"}`).Code)
+		assert.Equal(t, http.StatusConflict, performRequest(router, "POST", "/api/v1/custom-fields", `{"entity_type": "member", "key": "level", "type": "string"}`).Code)
+	})
+
+	t.Run("Validate values by type", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnprocessableEntity, performRequest(router, "POST", "/api/v1/members", `{"name": "A", "email": "a@example.com", "custom_fields": {"level": "principal"}}`).Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, performRequest(router, "POST", "/api/v1/members", `{"name": "A", "email": "a@example.com", "custom_fields": {"badge_expiry": "next year"}}`).Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, performRequest(router, "POST", "/api/v1/members", `{"name": "A", "email": "a@example.com", "custom_fields": {"unknown": "x"}}`).Code)
+
+		w := performRequest(router, "POST", "/api/v1/members", `{"name": "A", "email": "a@example.com", "custom_fields": {"desk": "window"}}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		var response struct {
+			Error  string            `json:"error"`
+			Fields map[string]string `json:"fields"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "Validation failed", response.Error)
+		assert.Equal(t, map[string]string{"custom_fields.desk": "must be a number"}, response.Fields)
+	})
+
+	t.Run("Store and filter custom field values", func(t *testing.T) {
+		w := performRequest(router, "POST", "/api/v1/members", `{"name": "Kim", "email": "kim@example.com", "custom_fields": {"level": "senior", "desk": 12, "badge_expiry": "2027-03-31"}}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var kim TeamMember
+		json.Unmarshal(w.Body.Bytes(), &kim)
+		assert.Equal(t, "senior", kim.CustomFields["level"])
+		assert.Equal(t, float64(12), kim.CustomFields["desk"])
+
+		w = performRequest(router, "POST", "/api/v1/members", `{"name": "Lee", "email": "lee@example.com", "custom_fields": {"level": "junior"}}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		w = performRequest(router, "GET", "/api/v1/members?cf.level=senior", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		var members []TeamMember
+		json.Unmarshal(w.Body.Bytes(), &members)
+		assert.Len(t, members, 1)
+		assert.Equal(t, kim.ID, members[0].ID)
+
+		w = performRequest(router, "GET", "/api/v1/members?cf.desk=12.0&cf.level=senior", "")
+		json.Unmarshal(w.Body.Bytes(), &members)
+		assert.Len(t, members, 1)
+
+		assert.Equal(t, http.StatusBadRequest, performRequest(router, "GET", "/api/v1/members?cf.shoe_size=42", "").Code)
+	})
+
+	t.Run("Clear a value with null on update", func(t *testing.T) {
+		var kim TeamMember
+		DB.Where("email = ?", "kim@example.com").First(&kim)
+
+		w := performRequest(router, "PUT", "/api/v1/members/"+strconv.Itoa(int(kim.ID)), `{"name": "Kim", "email": "kim@example.com", "custom_fields": {"desk": null, "level": "junior"}}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = performRequest(router, "GET", "/api/v1/members/"+strconv.Itoa(int(kim.ID)), "")
+		var member TeamMember
+		json.Unmarshal(w.Body.Bytes(), &member)
+		assert.Equal(t, "junior", member.CustomFields["level"])
+		assert.Equal(t, "2027-03-31", member.CustomFields["badge_expiry"])
+		assert.NotContains(t, member.CustomFields, "desk")
+	})
+
+	t.Run("Team custom fields", func(t *testing.T) {
+		w := performRequest(router, "POST", "/api/v1/teams", `{"name": "Finance", "custom_fields": {"cost_center": "CC-42"}}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		performRequest(router, "POST", "/api/v1/teams", `{"name": "Sales"}`)
+
+		w = performRequest(router, "GET", "/api/v1/teams?cf.cost_center=CC-42", "")
+		var teams []Team
+		json.Unmarshal(w.Body.Bytes(), &teams)
+		assert.Len(t, teams, 1)
+		assert.Equal(t, "CC-42", teams[0].CustomFields["cost_center"])
+
+		assert.Equal(t, http.StatusCreated, performRequest(router, "POST", "/api/v1/teams", `{"name": "Zürich", "custom_fields": {"cost_center": "`+strings.Repeat("é", 255)+`"}}`).Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, performRequest(router, "POST", "/api/v1/teams", `{"name": "Genève", "custom_fields": {"cost_center": "`+strings.Repeat("é", 256)+`"}}`).Code)
+	})
+
+	t.Run("Deleting a definition removes its values", func(t *testing.T) {
+		var definition CustomFieldDefinition
+		DB.Where("field_key = ?", "level").First(&definition)
+
+		w := performRequest(router, "DELETE", "/api/v1/custom-fields/"+strconv.Itoa(int(definition.ID)), "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var count int64
+		DB.Model(&CustomFieldValue{}).Where("definition_id = ?", definition.ID).Count(&count)
+		assert.Equal(t, int64(0), count)
+	})
+}
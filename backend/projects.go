@@ -0,0 +1,189 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func isProjectMember(db *gorm.DB, projectID, memberID uint) bool {
+	var count int64
+	db.Table("project_members").Where("project_id = ? AND team_member_id = ?", projectID, memberID).Count(&count)
+	if count > 0 {
+		return true
+	}
+	db.Table("project_teams").
+		Joins("JOIN member_teams ON member_teams.team_id = project_teams.team_id").
+		Where("project_teams.project_id = ? AND member_teams.team_member_id = ?", projectID, memberID).
+		Count(&count)
+	return count > 0
+}
+
+func loadProject(id string) (*Project, error) {
+	var project Project
+	err := DB.Preload("Members").Preload("Teams").First(&project, id).Error
+	return &project, err
+}
+
+func applyProjectInput(project *Project, input *ProjectInput) (int, string) {
+	project.Name = strings.TrimSpace(input.Name)
+	project.Description = input.Description
+	project.StartDate = input.StartDate
+	project.EndDate = input.EndDate
+
+	if project.Name == "" {
+		return http.StatusBadRequest, "Name is required"
+	}
+	if project.StartDate != nil && project.EndDate != nil && project.EndDate.Before(*project.StartDate) {
+		return http.StatusBadRequest, "End date must not be before start date"
+	}
+
+	project.Members = []TeamMember{}
+	if len(input.MemberIDs) > 0 {
+		if err := DB.Find(&project.Members, input.MemberIDs).Error; err != nil {
+			return http.StatusInternalServerError, err.Error()
+		}
+		if len(project.Members) != len(uniqueIDs(input.MemberIDs)) {
+			return http.StatusNotFound, "Team member not found"
+		}
+	}
+
+	project.Teams = []Team{}
+	if len(input.TeamIDs) > 0 {
+		if err := DB.Find(&project.Teams, input.TeamIDs).Error; err != nil {
+			return http.StatusInternalServerError, err.Error()
+		}
+		if len(project.Teams) != len(uniqueIDs(input.TeamIDs)) {
+			return http.StatusNotFound, "Team not found"
+		}
+	}
+	return 0, ""
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := map[uint]bool{}
+	unique := []uint{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func CreateProject(c *gin.Context) {
+	var input ProjectInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var project Project
+	if code, msg := applyProjectInput(&project, &input); msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	if err := DB.Create(&project).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, project)
+}
+
+func GetProjects(c *gin.Context) {
+	query := DB.Preload("Members").Preload("Teams").Order("name")
+	if memberID := c.Query("member_id"); memberID != "" {
+		query = query.Where("id IN (?)", DB.Table("project_members").Select("project_id").Where("team_member_id = ?", memberID))
+	}
+	if teamID := c.Query("team_id"); teamID != "" {
+		query = query.Where("id IN (?)", DB.Table("project_teams").Select("project_id").Where("team_id = ?", teamID))
+	}
+
+	var projects []Project
+	if err := query.Find(&projects).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, projects)
+}
+
+func GetProject(c *gin.Context) {
+	project, err := loadProject(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+func UpdateProject(c *gin.Context) {
+	project, err := loadProject(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	var input ProjectInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if code, msg := applyProjectInput(project, &input); msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Members", "Teams").Save(project).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(project).Association("Members").Replace(project.Members); err != nil {
+			return err
+		}
+		return tx.Model(project).Association("Teams").Replace(project.Teams)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+func DeleteProject(c *gin.Context) {
+	project, err := loadProject(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	policy, reassignTo, code, msg := parseFeedbackDeletePolicy(c, "project", project.ID)
+	if msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if err := applyFeedbackDeletePolicy(tx, "project", project.ID, policy, reassignTo); err != nil {
+			return err
+		}
+		if err := tx.Model(project).Association("Members").Clear(); err != nil {
+			return err
+		}
+		if err := tx.Model(project).Association("Teams").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(project).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Project deleted"})
+}
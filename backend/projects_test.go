@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjects(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	alice := TeamMember{Name: "Alice", Email: "alice@example.com"}
+	bob := TeamMember{Name: "Bob", Email: "bob@example.com"}
+	carol := TeamMember{Name: "Carol", Email: "carol@example.com"}
+	DB.Create(&alice)
+	DB.Create(&bob)
+	DB.Create(&carol)
+	team := Team{Name: "Platform", Members: []TeamMember{bob}}
+	DB.Create(&team)
+
+	var project Project
+
+	t.Run("Create project with members and teams", func(t *testing.T) {
+		body := `{"name": "Checkout rewrite", "start_date": "2026-01-01T00:00:00Z", "end_date": "2026-06-30T00:00:00Z",
+			"member_ids": [` + strconv.Itoa(int(alice.ID)) + `], "team_ids": [` + strconv.Itoa(int(team.ID)) + `]}`
+		w := performRequest(router, "POST", "/api/v1/projects", body)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		json.Unmarshal(w.Body.Bytes(), &project)
+		assert.Len(t, project.Members, 1)
+		assert.Len(t, project.Teams, 1)
+	})
+
+	t.Run("Reject end before start", func(t *testing.T) {
+		w := performRequest(router, "POST", "/api/v1/projects", `{"name": "Backwards", "start_date": "2026-06-01T00:00:00Z", "end_date": "2026-01-01T00:00:00Z"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Reject unknown member", func(t *testing.T) {
+		w := performRequest(router, "POST", "/api/v1/projects", `{"name": "Ghost", "member_ids": [999]}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Feedback on a project", func(t *testing.T) {
+		w := performRequest(router, "POST", "/api/v1/feedback", `{"target_type": "project", "target_id": `+strconv.Itoa(int(project.ID))+`, "content": "Smooth launch"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var feedback Feedback
+		json.Unmarshal(w.Body.Bytes(), &feedback)
+		assert.True(t, isFeedbackTarget(&feedback, alice.ID))
+		assert.True(t, isFeedbackTarget(&feedback, bob.ID))
+		assert.False(t, isFeedbackTarget(&feedback, carol.ID))
+
+		w = performRequest(router, "POST", "/api/v1/feedback", `{"target_type": "project", "target_id": 999, "content": "Nope"}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Update replaces members", func(t *testing.T) {
+		body := `{"name": "Checkout rewrite", "member_ids": [` + strconv.Itoa(int(carol.ID)) + `]}`
+		w := performRequest(router, "PUT", "/api/v1/projects/"+strconv.Itoa(int(project.ID)), body)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		reloaded, _ := loadProject(strconv.Itoa(int(project.ID)))
+		assert.Len(t, reloaded.Members, 1)
+		assert.Equal(t, carol.ID, reloaded.Members[0].ID)
+		assert.Len(t, reloaded.Teams, 0)
+	})
+
+	t.Run("Delete project archives its feedback", func(t *testing.T) {
+		w := performRequest(router, "DELETE", "/api/v1/projects/"+strconv.Itoa(int(project.ID)), "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		report, err := CheckFeedbackIntegrity(DB, false)
+		assert.NoError(t, err)
+		assert.Empty(t, report.OrphanedTargets)
+	})
+}
@@ -0,0 +1,455 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var defaultRetroColumns = []string{"Went well", "To improve", "Ideas"}
+
+func isTeamMember(teamID, memberID uint) bool {
+	var count int64
+	DB.Table("member_teams").Where("team_id = ? AND team_member_id = ?", teamID, memberID).Count(&count)
+	return count > 0
+}
+
+func loadRetrospective(id string) (*Retrospective, error) {
+	var retro Retrospective
+	err := DB.Preload("Columns", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Cards").
+		First(&retro, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var counts []struct {
+		RetroCardID uint
+		Votes       int
+	}
+	DB.Model(&RetroVote{}).
+		Select("retro_card_id, COUNT(*) AS votes").
+		Where("retro_card_id IN (?)", DB.Model(&RetroCard{}).Select("id").Where("retrospective_id = ?", retro.ID)).
+		Group("retro_card_id").
+		Scan(&counts)
+
+	votes := map[uint]int{}
+	for _, count := range counts {
+		votes[count.RetroCardID] = count.Votes
+	}
+	for i := range retro.Cards {
+		retro.Cards[i].Votes = votes[retro.Cards[i].ID]
+	}
+	return &retro, nil
+}
+
+func findRetroCard(retro *Retrospective, cardID string) *RetroCard {
+	id, err := strconv.Atoi(cardID)
+	if err != nil {
+		return nil
+	}
+	for i := range retro.Cards {
+		if retro.Cards[i].ID == uint(id) {
+			return &retro.Cards[i]
+		}
+	}
+	return nil
+}
+
+func CreateRetrospective(c *gin.Context) {
+	var input RetrospectiveCreate
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var team Team
+	if err := DB.First(&team, input.TeamID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	retro := Retrospective{TeamID: team.ID, Title: input.Title, Status: "open", VotesPerMember: input.VotesPerMember}
+	if retro.Title == "" {
+		retro.Title = team.Name + " retrospective " + time.Now().Format("2006-01-02")
+	}
+	if retro.VotesPerMember <= 0 {
+		retro.VotesPerMember = 3
+	}
+
+	columns := input.Columns
+	if len(columns) == 0 {
+		columns = defaultRetroColumns
+	}
+	for i, name := range columns {
+		if name == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Column name is required"})
+			return
+		}
+		retro.Columns = append(retro.Columns, RetroColumn{Name: name, Position: i + 1})
+	}
+
+	if err := DB.Create(&retro).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, retro)
+}
+
+func GetRetrospectives(c *gin.Context) {
+	query := DB.Model(&Retrospective{})
+
+	if teamID := c.Query("team_id"); teamID != "" {
+		if id, err := strconv.Atoi(teamID); err == nil {
+			query = query.Where("team_id = ?", id)
+		}
+	}
+
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var retros []Retrospective
+	if err := query.Order("created_at desc, id desc").Find(&retros).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, retros)
+}
+
+func GetRetrospective(c *gin.Context) {
+	retro, err := loadRetrospective(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Retrospective not found"})
+		return
+	}
+	c.JSON(http.StatusOK, retro)
+}
+
+func AddRetroCard(c *gin.Context) {
+	retro, err := loadRetrospective(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Retrospective not found"})
+		return
+	}
+
+	var card RetroCard
+	if err := c.ShouldBindJSON(&card); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if retro.Status != "open" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Retrospective is closed"})
+		return
+	}
+
+	if card.Content == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Content is required"})
+		return
+	}
+
+	validColumn := false
+	for _, column := range retro.Columns {
+		if column.ID == card.RetroColumnID {
+			validColumn = true
+		}
+	}
+	if !validColumn {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Column does not belong to this retrospective"})
+		return
+	}
+
+	if card.AuthorID != nil && !isTeamMember(retro.TeamID, *card.AuthorID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only members of the team can add cards"})
+		return
+	}
+
+	card.ID = 0
+	card.GroupID = nil
+	card.Votes = 0
+	card.RetrospectiveID = retro.ID
+	if err := DB.Create(&card).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, card)
+}
+
+func VoteRetroCard(c *gin.Context) {
+	retro, err := loadRetrospective(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Retrospective not found"})
+		return
+	}
+
+	card := findRetroCard(retro, c.Param("cardId"))
+	if card == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Card not found"})
+		return
+	}
+
+	var request RetroVoteRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if retro.Status != "open" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Retrospective is closed"})
+		return
+	}
+
+	if !isTeamMember(retro.TeamID, request.MemberID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only members of the team can vote"})
+		return
+	}
+
+	var used int64
+	DB.Model(&RetroVote{}).
+		Where("member_id = ? AND retro_card_id IN (?)", request.MemberID,
+			DB.Model(&RetroCard{}).Select("id").Where("retrospective_id = ?", retro.ID)).
+		Count(&used)
+	if int(used) >= retro.VotesPerMember {
+		c.JSON(http.StatusConflict, gin.H{"error": "No votes left for this retrospective"})
+		return
+	}
+
+	var existing int64
+	DB.Model(&RetroVote{}).Where("retro_card_id = ? AND member_id = ?", card.ID, request.MemberID).Count(&existing)
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Member has already voted for this card"})
+		return
+	}
+
+	if err := DB.Create(&RetroVote{RetroCardID: card.ID, MemberID: request.MemberID}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	card.Votes++
+	c.JSON(http.StatusOK, card)
+}
+
+func RemoveRetroVote(c *gin.Context) {
+	retro, err := loadRetrospective(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Retrospective not found"})
+		return
+	}
+
+	card := findRetroCard(retro, c.Param("cardId"))
+	if card == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Card not found"})
+		return
+	}
+
+	if retro.Status != "open" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Retrospective is closed"})
+		return
+	}
+
+	if err := DB.Where("retro_card_id = ? AND member_id = ?", card.ID, c.Param("memberId")).Delete(&RetroVote{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Vote removed"})
+}
+
+func GroupRetroCard(c *gin.Context) {
+	retro, err := loadRetrospective(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Retrospective not found"})
+		return
+	}
+
+	card := findRetroCard(retro, c.Param("cardId"))
+	if card == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Card not found"})
+		return
+	}
+
+	var request RetroCardGroupRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if retro.Status != "open" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Retrospective is closed"})
+		return
+	}
+
+	if request.GroupID != nil {
+		parent := findRetroCard(retro, strconv.Itoa(int(*request.GroupID)))
+		if parent == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Group card does not belong to this retrospective"})
+			return
+		}
+		if parent.GroupID != nil {
+			parent = findRetroCard(retro, strconv.Itoa(int(*parent.GroupID)))
+		}
+		if parent.ID == card.ID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "A card cannot be grouped with itself"})
+			return
+		}
+		request.GroupID = &parent.ID
+	}
+
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if request.GroupID != nil {
+			if err := tx.Model(&RetroCard{}).Where("group_id = ?", card.ID).Update("group_id", *request.GroupID).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(card).Update("group_id", request.GroupID).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	card.GroupID = request.GroupID
+	c.JSON(http.StatusOK, card)
+}
+
+func CreateRetroActionItem(c *gin.Context) {
+	retro, err := loadRetrospective(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Retrospective not found"})
+		return
+	}
+
+	var request RetroActionItemRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if retro.Status != "open" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Retrospective is closed"})
+		return
+	}
+
+	meetingDate := retro.CreatedAt
+	item := ActionItem{
+		Title:           request.Title,
+		Description:     request.Description,
+		AssigneeID:      request.AssigneeID,
+		DueDate:         request.DueDate,
+		MeetingTitle:    retro.Title,
+		MeetingDate:     &meetingDate,
+		RetrospectiveID: &retro.ID,
+		Status:          "open",
+	}
+
+	if code, msg := validateActionItem(&item); msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	if !isTeamMember(retro.TeamID, item.AssigneeID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Assignee must be a member of the team"})
+		return
+	}
+
+	if err := DB.Create(&item).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+func retrospectiveSummary(retro *Retrospective, actionItems []ActionItem) string {
+	grouped := map[uint][]RetroCard{}
+	for _, card := range retro.Cards {
+		if card.GroupID != nil {
+			grouped[*card.GroupID] = append(grouped[*card.GroupID], card)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Retrospective: %s\n", retro.Title)
+	for _, column := range retro.Columns {
+		cards := []RetroCard{}
+		for _, card := range retro.Cards {
+			if card.RetroColumnID == column.ID && card.GroupID == nil {
+				for _, child := range grouped[card.ID] {
+					card.Votes += child.Votes
+				}
+				cards = append(cards, card)
+			}
+		}
+		if len(cards) == 0 {
+			continue
+		}
+		sort.SliceStable(cards, func(i, j int) bool { return cards[i].Votes > cards[j].Votes })
+
+		fmt.Fprintf(&b, "\n%s:\n", column.Name)
+		for _, card := range cards {
+			fmt.Fprintf(&b, "- %s (%d votes)\n", card.Content, card.Votes)
+			for _, child := range grouped[card.ID] {
+				fmt.Fprintf(&b, "  - %s\n", child.Content)
+			}
+		}
+	}
+
+	if len(actionItems) > 0 {
+		b.WriteString("\nAction items:\n")
+		for _, item := range actionItems {
+			fmt.Fprintf(&b, "- %s\n", item.Title)
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func CloseRetrospective(c *gin.Context) {
+	retro, err := loadRetrospective(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Retrospective not found"})
+		return
+	}
+
+	if retro.Status != "open" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Retrospective is already closed"})
+		return
+	}
+
+	var actionItems []ActionItem
+	if err := DB.Where("retrospective_id = ?", retro.ID).Order("id").Find(&actionItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	feedback := Feedback{Content: retrospectiveSummary(retro, actionItems), TargetType: "team", TargetID: retro.TeamID}
+	scoreFeedback(&feedback)
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&feedback).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		retro.Status = "closed"
+		retro.ClosedAt = &now
+		retro.FeedbackID = &feedback.ID
+		return tx.Model(&Retrospective{ID: retro.ID}).Updates(map[string]interface{}{
+			"status":      retro.Status,
+			"closed_at":   retro.ClosedAt,
+			"feedback_id": retro.FeedbackID,
+		}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"retrospective": retro, "feedback": feedback})
+}
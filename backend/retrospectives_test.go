@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrospectiveBoard(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	member1 := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	member2 := TeamMember{Name: "Jane Smith", Email: "jane@example.com"}
+	outsider := TeamMember{Name: "Bob Johnson", Email: "bob@example.com"}
+	team := Team{Name: "Development Team"}
+	DB.Create(&member1)
+	DB.Create(&member2)
+	DB.Create(&outsider)
+	DB.Create(&team)
+	DB.Model(&team).Association("Members").Append(&member1, &member2)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	var retro Retrospective
+	w := post("/api/v1/retrospectives", `{"team_id": `+strconv.Itoa(int(team.ID))+`, "title": "Sprint 42", "votes_per_member": 2}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	json.Unmarshal(w.Body.Bytes(), &retro)
+	retroPath := "/api/v1/retrospectives/" + strconv.Itoa(int(retro.ID))
+
+	addCard := func(columnID uint, authorID uint, content string) RetroCard {
+		var card RetroCard
+		w := post(retroPath+"/cards", `{"column_id": `+strconv.Itoa(int(columnID))+`, "author_id": `+strconv.Itoa(int(authorID))+`, "content": "`+content+`"}`)
+		json.Unmarshal(w.Body.Bytes(), &card)
+		return card
+	}
+
+	vote := func(cardID, memberID uint) int {
+		return post(retroPath+"/cards/"+strconv.Itoa(int(cardID))+"/votes", `{"member_id": `+strconv.Itoa(int(memberID))+`}`).Code
+	}
+
+	t.Run("Default columns", func(t *testing.T) {
+		assert.Len(t, retro.Columns, 3)
+		assert.Equal(t, "Went well", retro.Columns[0].Name)
+	})
+
+	wentWell := retro.Columns[0].ID
+	toImprove := retro.Columns[1].ID
+	pairing := addCard(wentWell, member1.ID, "Pairing sessions")
+	mobbing := addCard(wentWell, member2.ID, "Mob programming")
+	flaky := addCard(toImprove, member2.ID, "Flaky CI")
+
+	t.Run("Only team members can add cards", func(t *testing.T) {
+		w := post(retroPath+"/cards", `{"column_id": `+strconv.Itoa(int(wentWell))+`, "author_id": `+strconv.Itoa(int(outsider.ID))+`, "content": "Hi"}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Dot voting with a budget", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, vote(flaky.ID, member1.ID))
+		assert.Equal(t, http.StatusConflict, vote(flaky.ID, member1.ID))
+		assert.Equal(t, http.StatusOK, vote(mobbing.ID, member1.ID))
+		assert.Equal(t, http.StatusConflict, vote(pairing.ID, member1.ID))
+		assert.Equal(t, http.StatusOK, vote(flaky.ID, member2.ID))
+		assert.Equal(t, http.StatusForbidden, vote(flaky.ID, outsider.ID))
+	})
+
+	t.Run("Group cards", func(t *testing.T) {
+		w := post(retroPath+"/cards/"+strconv.Itoa(int(mobbing.ID))+"/group", `{"group_id": `+strconv.Itoa(int(pairing.ID))+`}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = post(retroPath+"/cards/"+strconv.Itoa(int(pairing.ID))+"/group", `{"group_id": `+strconv.Itoa(int(mobbing.ID))+`}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Generate action item", func(t *testing.T) {
+		w := post(retroPath+"/action-items", `{"title": "Quarantine flaky tests", "assignee_id": `+strconv.Itoa(int(member2.ID))+`}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var item ActionItem
+		json.Unmarshal(w.Body.Bytes(), &item)
+		assert.Equal(t, retro.ID, *item.RetrospectiveID)
+		assert.Equal(t, "Sprint 42", item.MeetingTitle)
+
+		w = post(retroPath+"/action-items", `{"title": "Audit the pipeline", "assignee_id": `+strconv.Itoa(int(outsider.ID))+`}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Closing produces team feedback", func(t *testing.T) {
+		w := post(retroPath+"/close", `{}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Retrospective Retrospective `json:"retrospective"`
+			Feedback      Feedback      `json:"feedback"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "closed", response.Retrospective.Status)
+		assert.Equal(t, "team", response.Feedback.TargetType)
+		assert.Equal(t, team.ID, response.Feedback.TargetID)
+		assert.Contains(t, response.Feedback.Content, "- Pairing sessions (1 votes)\n  - Mob programming")
+		assert.Contains(t, response.Feedback.Content, "- Flaky CI (2 votes)")
+		assert.Contains(t, response.Feedback.Content, "Action items:\n- Quarantine flaky tests")
+
+		assert.Equal(t, http.StatusConflict, post(retroPath+"/close", `{}`).Code)
+		assert.Equal(t, http.StatusConflict, vote(pairing.ID, member2.ID))
+		assert.Equal(t, http.StatusConflict, post(retroPath+"/action-items", `{"title": "Too late", "assignee_id": `+strconv.Itoa(int(member2.ID))+`}`).Code)
+	})
+}
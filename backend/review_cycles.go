@@ -0,0 +1,442 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var reviewCycleTransitions = map[string][]string{
+	"draft":       {"open"},
+	"open":        {"calibration"},
+	"calibration": {"open", "closed"},
+	"closed":      {},
+}
+
+func canTransitionReviewCycle(from, to string) bool {
+	for _, next := range reviewCycleTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func applyReviewCycleRequest(cycle *ReviewCycle, request ReviewCycleRequest) (int, string) {
+	if request.Name == "" {
+		return http.StatusBadRequest, "Name is required"
+	}
+	if request.PeriodStart.IsZero() || request.PeriodEnd.IsZero() || !request.PeriodEnd.After(request.PeriodStart) {
+		return http.StatusBadRequest, "Period end must be after period start"
+	}
+
+	var teams []Team
+	if len(request.TeamIDs) > 0 {
+		if err := DB.Find(&teams, request.TeamIDs).Error; err != nil {
+			return http.StatusInternalServerError, err.Error()
+		}
+		if len(teams) != len(request.TeamIDs) {
+			return http.StatusNotFound, "Team not found"
+		}
+	}
+
+	cycle.Name = request.Name
+	cycle.PeriodStart = request.PeriodStart
+	cycle.PeriodEnd = request.PeriodEnd
+	cycle.SelfReviewDeadline = request.SelfReviewDeadline
+	cycle.PeerReviewDeadline = request.PeerReviewDeadline
+	cycle.Teams = teams
+	return 0, ""
+}
+
+func reviewCycleParticipants(cycle *ReviewCycle) ([]TeamMember, error) {
+	var participants []TeamMember
+	err := DB.Where("id IN (?)", DB.Table("member_teams").
+		Select("team_member_id").
+		Where("team_id IN (?)", DB.Table("review_cycle_teams").Select("team_id").Where("review_cycle_id = ?", cycle.ID))).
+		Order("name").
+		Find(&participants).Error
+	return participants, err
+}
+
+func isReviewCycleParticipant(cycle *ReviewCycle, memberID uint) bool {
+	participants, err := reviewCycleParticipants(cycle)
+	if err != nil {
+		return false
+	}
+	for _, participant := range participants {
+		if participant.ID == memberID {
+			return true
+		}
+	}
+	return false
+}
+
+func CreateReviewCycle(c *gin.Context) {
+	var request ReviewCycleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cycle := ReviewCycle{Status: "draft"}
+	if code, msg := applyReviewCycleRequest(&cycle, request); msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	if err := DB.Create(&cycle).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, cycle)
+}
+
+func GetReviewCycles(c *gin.Context) {
+	query := DB.Preload("Teams")
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var cycles []ReviewCycle
+	if err := query.Order("period_start desc").Find(&cycles).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, cycles)
+}
+
+func GetReviewCycle(c *gin.Context) {
+	id := c.Param("id")
+	var cycle ReviewCycle
+	if err := DB.Preload("Teams").First(&cycle, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review cycle not found"})
+		return
+	}
+	c.JSON(http.StatusOK, cycle)
+}
+
+func UpdateReviewCycle(c *gin.Context) {
+	id := c.Param("id")
+	var cycle ReviewCycle
+	if err := DB.First(&cycle, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review cycle not found"})
+		return
+	}
+
+	if cycle.Status != "draft" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only draft review cycles can be edited"})
+		return
+	}
+
+	var request ReviewCycleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if code, msg := applyReviewCycleRequest(&cycle, request); msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Teams").Save(&cycle).Error; err != nil {
+			return err
+		}
+		return tx.Model(&cycle).Association("Teams").Replace(cycle.Teams)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cycle)
+}
+
+func DeleteReviewCycle(c *gin.Context) {
+	id := c.Param("id")
+	var cycle ReviewCycle
+	if err := DB.First(&cycle, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review cycle not found"})
+		return
+	}
+
+	if cycle.Status != "draft" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only draft review cycles can be deleted"})
+		return
+	}
+
+	if err := DB.Model(&cycle).Association("Teams").Clear(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := DB.Delete(&cycle).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Review cycle deleted"})
+}
+
+func TransitionReviewCycle(c *gin.Context) {
+	id := c.Param("id")
+	var cycle ReviewCycle
+	if err := DB.First(&cycle, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review cycle not found"})
+		return
+	}
+
+	var transition ReviewCycleTransition
+	if err := c.ShouldBindJSON(&transition); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, ok := reviewCycleTransitions[transition.Status]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status must be one of 'draft', 'open', 'calibration' or 'closed'"})
+		return
+	}
+
+	if !canTransitionReviewCycle(cycle.Status, transition.Status) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Cannot change status from '" + cycle.Status + "' to '" + transition.Status + "'"})
+		return
+	}
+
+	if transition.Status == "open" {
+		participants, err := reviewCycleParticipants(&cycle)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if len(participants) == 0 {
+			c.JSON(http.StatusConflict, gin.H{"error": "Review cycle has no participants"})
+			return
+		}
+	}
+
+	cycle.Status = transition.Status
+	if err := DB.Model(&cycle).Update("status", cycle.Status).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cycle)
+}
+
+func GetReviewCycleParticipants(c *gin.Context) {
+	id := c.Param("id")
+	var cycle ReviewCycle
+	if err := DB.First(&cycle, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review cycle not found"})
+		return
+	}
+
+	participants, err := reviewCycleParticipants(&cycle)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, participants)
+}
+
+func SubmitReview(c *gin.Context) {
+	id := c.Param("id")
+	var cycle ReviewCycle
+	if err := DB.First(&cycle, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review cycle not found"})
+		return
+	}
+
+	var submission ReviewSubmission
+	if err := c.ShouldBindJSON(&submission); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if cycle.Status != "open" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Review cycle is not open"})
+		return
+	}
+
+	var deadline *time.Time
+	switch submission.Type {
+	case "self":
+		if submission.ReviewerID != submission.RevieweeID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Self review must have the same reviewer and reviewee"})
+			return
+		}
+		deadline = cycle.SelfReviewDeadline
+	case "peer":
+		if submission.ReviewerID == submission.RevieweeID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Peer review cannot target the reviewer"})
+			return
+		}
+		deadline = cycle.PeerReviewDeadline
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Review type must be 'self' or 'peer'"})
+		return
+	}
+
+	if deadline != nil && time.Now().After(*deadline) {
+		c.JSON(http.StatusConflict, gin.H{"error": "The " + submission.Type + " review deadline has passed"})
+		return
+	}
+
+	if !isReviewCycleParticipant(&cycle, submission.ReviewerID) || !isReviewCycleParticipant(&cycle, submission.RevieweeID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Reviewer and reviewee must be participants of the review cycle"})
+		return
+	}
+
+	if msg := validateCompetencyRatings(submission.Competencies, submission.RevieweeID); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	var review Review
+	err := DB.Where("review_cycle_id = ? AND type = ? AND reviewer_id = ? AND reviewee_id = ?",
+		cycle.ID, submission.Type, submission.ReviewerID, submission.RevieweeID).First(&review).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if review.Status == "submitted" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Review has already been submitted"})
+		return
+	}
+
+	review.ReviewCycleID = cycle.ID
+	review.Type = submission.Type
+	review.ReviewerID = &submission.ReviewerID
+	review.RevieweeID = submission.RevieweeID
+	review.Strengths = submission.Strengths
+	review.Improvements = submission.Improvements
+	review.Comments = submission.Comments
+	review.Status = "draft"
+	if submission.Submit {
+		now := time.Now()
+		review.Status = "submitted"
+		review.SubmittedAt = &now
+	}
+
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Competencies").Save(&review).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("review_id = ?", review.ID).Delete(&CompetencyRating{}).Error; err != nil {
+			return err
+		}
+		review.Competencies = submission.Competencies
+		for i := range review.Competencies {
+			review.Competencies[i].ReviewID = &review.ID
+		}
+		if len(review.Competencies) == 0 {
+			return nil
+		}
+		return tx.Create(&review.Competencies).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+func GetReviews(c *gin.Context) {
+	query := DB.Where("review_cycle_id = ?", c.Param("id"))
+
+	if revieweeID := c.Query("reviewee_id"); revieweeID != "" {
+		if id, err := strconv.Atoi(revieweeID); err == nil {
+			query = query.Where("reviewee_id = ?", id)
+		}
+	}
+
+	if reviewerID := c.Query("reviewer_id"); reviewerID != "" {
+		if id, err := strconv.Atoi(reviewerID); err == nil {
+			query = query.Where("reviewer_id = ?", id)
+		}
+	}
+
+	var reviews []Review
+	if err := query.Preload("Competencies").Find(&reviews).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, reviews)
+}
+
+func GetReviewPacket(c *gin.Context) {
+	var cycle ReviewCycle
+	if err := DB.Preload("Teams").First(&cycle, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Review cycle not found"})
+		return
+	}
+
+	var member TeamMember
+	if err := DB.Preload("Teams").First(&member, c.Param("memberId")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	if !isReviewCycleParticipant(&cycle, member.ID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member is not a participant of the review cycle"})
+		return
+	}
+
+	var feedbacks []Feedback
+	if err := DB.Scopes(revealedFeedback, publishedFeedback, activeFeedback).Where("target_type = ? AND target_id = ? AND COALESCE(release_at, created_at) BETWEEN ? AND ?",
+		"member", member.ID, cycle.PeriodStart, cycle.PeriodEnd).
+		Order("COALESCE(release_at, created_at)").Find(&feedbacks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	teamIDs := []uint{}
+	for _, team := range member.Teams {
+		teamIDs = append(teamIDs, team.ID)
+	}
+
+	teamFeedbacks := []Feedback{}
+	if len(teamIDs) > 0 {
+		if err := DB.Scopes(revealedFeedback, publishedFeedback, activeFeedback).Where("target_type = ? AND target_id IN ? AND COALESCE(release_at, created_at) BETWEEN ? AND ?",
+			"team", teamIDs, cycle.PeriodStart, cycle.PeriodEnd).
+			Order("COALESCE(release_at, created_at)").Find(&teamFeedbacks).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	var reviews []Review
+	if err := DB.Preload("Competencies").Where("review_cycle_id = ? AND reviewee_id = ? AND status = ?", cycle.ID, member.ID, "submitted").
+		Find(&reviews).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var selfReview *Review
+	peerReviews := []Review{}
+	for i := range reviews {
+		if reviews[i].Type == "self" {
+			selfReview = &reviews[i]
+		} else {
+			peerReviews = append(peerReviews, reviews[i])
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"review_cycle":   cycle,
+		"member":         member,
+		"feedback":       feedbacks,
+		"team_feedback":  teamFeedbacks,
+		"self_review":    selfReview,
+		"peer_reviews":   peerReviews,
+		"feedback_count": len(feedbacks) + len(teamFeedbacks),
+	})
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewCycleLifecycle(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	member1 := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	member2 := TeamMember{Name: "Jane Smith", Email: "jane@example.com"}
+	outsider := TeamMember{Name: "Bob Johnson", Email: "bob@example.com"}
+	team := Team{Name: "Development Team"}
+	DB.Create(&member1)
+	DB.Create(&member2)
+	DB.Create(&outsider)
+	DB.Create(&team)
+	DB.Model(&team).Association("Members").Append(&member1, &member2)
+
+	start := time.Now().AddDate(0, -6, 0)
+	end := time.Now().AddDate(0, 0, 1)
+	DB.Create(&Feedback{Content: "Great mentoring", TargetType: "member", TargetID: member1.ID})
+	DB.Create(&Feedback{Content: "Old news", TargetType: "member", TargetID: member1.ID, CreatedAt: start.AddDate(0, -1, 0)})
+	DB.Create(&Feedback{Content: "Solid sprint", TargetType: "team", TargetID: team.ID})
+	releasedAt := start.AddDate(0, 1, 0)
+	archivedAt := time.Now()
+	DB.Create(&Feedback{Content: "Released in the cycle", TargetType: "member", TargetID: member1.ID, CreatedAt: start.AddDate(0, -1, 0), ReleaseAt: &releasedAt})
+	DB.Create(&Feedback{Content: "Archived", TargetType: "member", TargetID: member1.ID, ArchivedAt: &archivedAt})
+
+	var cycle ReviewCycle
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Create review cycle for a team", func(t *testing.T) {
+		request := ReviewCycleRequest{Name: "H1 2026", PeriodStart: start, PeriodEnd: end, TeamIDs: []uint{team.ID}}
+		jsonValue, _ := json.Marshal(request)
+		w := post("/api/v1/review-cycles", string(jsonValue))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &cycle)
+		assert.Equal(t, "draft", cycle.Status)
+		assert.Len(t, cycle.Teams, 1)
+	})
+
+	cyclePath := "/api/v1/review-cycles/" + strconv.Itoa(int(cycle.ID))
+
+	t.Run("Reviews are rejected while draft", func(t *testing.T) {
+		body := `{"type": "self", "reviewer_id": ` + strconv.Itoa(int(member1.ID)) + `, "reviewee_id": ` + strconv.Itoa(int(member1.ID)) + `}`
+		assert.Equal(t, http.StatusConflict, post(cyclePath+"/reviews", body).Code)
+	})
+
+	t.Run("Invalid transition", func(t *testing.T) {
+		assert.Equal(t, http.StatusConflict, post(cyclePath+"/transition", `{"status": "closed"}`).Code)
+	})
+
+	t.Run("Open cycle and submit reviews", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, post(cyclePath+"/transition", `{"status": "open"}`).Code)
+
+		self := `{"type": "self", "reviewer_id": ` + strconv.Itoa(int(member1.ID)) + `, "reviewee_id": ` + strconv.Itoa(int(member1.ID)) + `, "strengths": "Mentoring", "submit": true}`
+		assert.Equal(t, http.StatusOK, post(cyclePath+"/reviews", self).Code)
+
+		competency := Competency{Name: "Mentoring"}
+		DB.Create(&competency)
+		peer := `{"type": "peer", "reviewer_id": ` + strconv.Itoa(int(member2.ID)) + `, "reviewee_id": ` + strconv.Itoa(int(member1.ID)) + `, "strengths": "Helpful", "submit": true,
+			"competencies": [{"competency_id": ` + strconv.Itoa(int(competency.ID)) + `, "rating": 4}]}`
+		w := post(cyclePath+"/reviews", peer)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var review Review
+		json.Unmarshal(w.Body.Bytes(), &review)
+		assert.Len(t, review.Competencies, 1)
+		assert.Equal(t, member1.ID, review.Competencies[0].MemberID)
+
+		assert.Equal(t, http.StatusConflict, post(cyclePath+"/reviews", self).Code)
+
+		outsiderPeer := `{"type": "peer", "reviewer_id": ` + strconv.Itoa(int(outsider.ID)) + `, "reviewee_id": ` + strconv.Itoa(int(member1.ID)) + `, "submit": true}`
+		assert.Equal(t, http.StatusBadRequest, post(cyclePath+"/reviews", outsiderPeer).Code)
+	})
+
+	t.Run("Compile review packet", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", cyclePath+"/packets/"+strconv.Itoa(int(member1.ID)), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Feedback     []Feedback `json:"feedback"`
+			TeamFeedback []Feedback `json:"team_feedback"`
+			SelfReview   *Review    `json:"self_review"`
+			PeerReviews  []Review   `json:"peer_reviews"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response.Feedback, 2)
+		assert.Equal(t, "Released in the cycle", response.Feedback[0].Content)
+		assert.Equal(t, "Great mentoring", response.Feedback[1].Content)
+		assert.Len(t, response.TeamFeedback, 1)
+		assert.NotNil(t, response.SelfReview)
+		assert.Len(t, response.PeerReviews, 1)
+	})
+
+	t.Run("Close cycle through calibration", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, post(cyclePath+"/transition", `{"status": "calibration"}`).Code)
+		assert.Equal(t, http.StatusOK, post(cyclePath+"/transition", `{"status": "closed"}`).Code)
+		assert.Equal(t, http.StatusConflict, post(cyclePath+"/transition", `{"status": "open"}`).Code)
+	})
+}
+
+func TestReviewDeadline(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	member := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	team := Team{Name: "Development Team"}
+	DB.Create(&member)
+	DB.Create(&team)
+	DB.Model(&team).Association("Members").Append(&member)
+
+	deadline := time.Now().Add(-time.Hour)
+	cycle := ReviewCycle{
+		Name:               "H2 2026",
+		PeriodStart:        time.Now().AddDate(0, -6, 0),
+		PeriodEnd:          time.Now(),
+		SelfReviewDeadline: &deadline,
+		Status:             "open",
+		Teams:              []Team{team},
+	}
+	DB.Create(&cycle)
+
+	t.Run("Self review after deadline", func(t *testing.T) {
+		body := `{"type": "self", "reviewer_id": ` + strconv.Itoa(int(member.ID)) + `, "reviewee_id": ` + strconv.Itoa(int(member.ID)) + `, "submit": true}`
+		req, _ := http.NewRequest("POST", "/api/v1/review-cycles/"+strconv.Itoa(int(cycle.ID))+"/reviews", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
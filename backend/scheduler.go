@@ -0,0 +1,26 @@
+package main
+
+import (
+	"log"
+	"time"
+)
+
+var schedulerInterval = time.Duration(getEnvInt("SCHEDULER_INTERVAL_SECONDS", 60)) * time.Second
+
+func runScheduledJobs(now time.Time) {
+	if released, err := releaseScheduledFeedback(DB, now); err != nil {
+		log.Println("Failed to release scheduled feedback:", err)
+	} else if released > 0 {
+		log.Printf("Released %d scheduled feedback items", released)
+	}
+}
+
+func StartScheduler() {
+	ticker := time.NewTicker(schedulerInterval)
+	go func() {
+		runScheduledJobs(time.Now())
+		for now := range ticker.C {
+			runScheduledJobs(now)
+		}
+	}()
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	minFeedbackRating        = 1
+	maxFeedbackRating        = 5
+	sentimentThreshold       = 0.05
+	sentimentNormalization   = 15.0
+	sentimentNegationScale   = -0.75
+	sentimentIntensifierGain = 0.5
+)
+
+var sentimentLexicon = map[string]float64{
+	"amazing": 3, "awesome": 3, "excellent": 3, "outstanding": 3, "fantastic": 3, "brilliant": 3, "exceptional": 3,
+	"great": 2, "impressive": 2, "thanks": 2, "thank": 2, "love": 2, "helpful": 2, "proud": 2, "appreciate": 2, "appreciated": 2,
+	"clear": 1, "good": 1, "nice": 1, "solid": 1, "reliable": 1, "kind": 1, "supportive": 1, "thorough": 1, "improved": 1,
+	"well": 1, "progress": 1, "collaborative": 1, "calm": 1, "creative": 1, "responsive": 1, "strong": 1,
+	"terrible": -3, "awful": -3, "horrible": -3, "unacceptable": -3, "disrespectful": -3,
+	"bad": -2, "poor": -2, "rude": -2, "careless": -2, "frustrating": -2, "frustrated": -2, "failed": -2, "sloppy": -2,
+	"late": -1, "missed": -1, "confusing": -1, "confused": -1, "unclear": -1, "slow": -1, "problem": -1, "issue": -1,
+	"issues": -1, "mistake": -1, "mistakes": -1, "struggle": -1, "struggled": -1, "blocked": -1, "interrupted": -1,
+	"defensive": -1, "delay": -1, "delayed": -1, "worse": -1, "weak": -1, "unprepared": -1,
+}
+
+var sentimentNegations = map[string]bool{
+	"not": true, "no": true, "never": true, "without": true, "hardly": true, "barely": true,
+	"dont": true, "didnt": true, "doesnt": true, "isnt": true, "wasnt": true, "cant": true, "wont": true,
+}
+
+var sentimentIntensifiers = map[string]bool{
+	"very": true, "really": true, "extremely": true, "incredibly": true, "super": true, "so": true, "truly": true,
+}
+
+func sentimentTokens(text string) []string {
+	text = strings.ReplaceAll(strings.ToLower(text), "'", "")
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
+
+func scoreSentiment(text string) float64 {
+	tokens := sentimentTokens(text)
+	total := 0.0
+	for i, token := range tokens {
+		value, ok := sentimentLexicon[token]
+		if !ok {
+			continue
+		}
+		for j := i - 1; j >= 0 && j >= i-3; j-- {
+			if sentimentNegations[tokens[j]] {
+				value *= sentimentNegationScale
+				break
+			}
+			if j == i-1 && sentimentIntensifiers[tokens[j]] {
+				value += math.Copysign(sentimentIntensifierGain, value)
+			}
+		}
+		total += value
+	}
+	if total == 0 {
+		return 0
+	}
+	score := total / math.Sqrt(total*total+sentimentNormalization)
+	return math.Round(score*1000) / 1000
+}
+
+func scoreFeedback(feedback *Feedback) {
+	feedback.SentimentScore = scoreSentiment(feedback.Content)
+}
+
+func validFeedbackRating(rating *int) bool {
+	return rating == nil || (*rating >= minFeedbackRating && *rating <= maxFeedbackRating)
+}
+
+func sentimentLabel(score float64) string {
+	switch {
+	case score >= sentimentThreshold:
+		return "positive"
+	case score <= -sentimentThreshold:
+		return "negative"
+	default:
+		return "neutral"
+	}
+}
+
+func summarizeSentiment(feedbacks []Feedback) gin.H {
+	counts := map[string]int{"positive": 0, "neutral": 0, "negative": 0}
+	kinds := map[string]int{}
+	scoreTotal, ratingTotal, rated := 0.0, 0, 0
+	for _, feedback := range feedbacks {
+		counts[sentimentLabel(feedback.SentimentScore)]++
+		kinds[feedback.Kind]++
+		scoreTotal += feedback.SentimentScore
+		if feedback.Rating != nil {
+			ratingTotal += *feedback.Rating
+			rated++
+		}
+	}
+
+	summary := gin.H{
+		"total":             len(feedbacks),
+		"sentiment":         counts,
+		"kinds":             kinds,
+		"average_sentiment": nil,
+		"average_rating":    nil,
+		"positive_ratio":    nil,
+	}
+	if len(feedbacks) > 0 {
+		summary["average_sentiment"] = math.Round(scoreTotal/float64(len(feedbacks))*1000) / 1000
+	}
+	if rated > 0 {
+		summary["average_rating"] = math.Round(float64(ratingTotal)/float64(rated)*100) / 100
+	}
+	if opinionated := counts["positive"] + counts["negative"]; opinionated > 0 {
+		summary["positive_ratio"] = math.Round(float64(counts["positive"])/float64(opinionated)*100) / 100
+	}
+	return summary
+}
+
+func GetMemberSentiment(c *gin.Context) {
+	id := c.Param("id")
+	var member TeamMember
+	if err := DB.First(&member, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	var feedbacks []Feedback
+	if err := DB.Scopes(revealedFeedback, publishedFeedback, activeFeedback).Where("target_type = ? AND target_id = ?", "member", member.ID).Find(&feedbacks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	summary := summarizeSentiment(feedbacks)
+	summary["member_id"] = member.ID
+	c.JSON(http.StatusOK, summary)
+}
+
+func GetTeamSentiment(c *gin.Context) {
+	id := c.Param("id")
+	var team Team
+	if err := DB.Preload("Members").First(&team, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	memberIDs := []uint{}
+	for _, member := range team.Members {
+		memberIDs = append(memberIDs, member.ID)
+	}
+
+	var feedbacks []Feedback
+	query := DB.Scopes(revealedFeedback, publishedFeedback, activeFeedback)
+	if len(memberIDs) > 0 {
+		query = query.Where("(target_type = ? AND target_id = ?) OR (target_type = ? AND target_id IN ?)", "team", team.ID, "member", memberIDs)
+	} else {
+		query = query.Where("target_type = ? AND target_id = ?", "team", team.ID)
+	}
+	if err := query.Find(&feedbacks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	teamFeedbacks := []Feedback{}
+	byMember := map[uint][]Feedback{}
+	for _, feedback := range feedbacks {
+		if feedback.TargetType == "team" {
+			teamFeedbacks = append(teamFeedbacks, feedback)
+		} else {
+			byMember[feedback.TargetID] = append(byMember[feedback.TargetID], feedback)
+		}
+	}
+
+	members := []gin.H{}
+	for _, member := range team.Members {
+		summary := summarizeSentiment(byMember[member.ID])
+		summary["member_id"] = member.ID
+		summary["name"] = member.Name
+		members = append(members, summary)
+	}
+
+	summary := summarizeSentiment(feedbacks)
+	summary["team_id"] = team.ID
+	summary["team_feedback"] = summarizeSentiment(teamFeedbacks)
+	summary["members"] = members
+	c.JSON(http.StatusOK, summary)
+}
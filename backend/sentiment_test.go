@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreSentiment(t *testing.T) {
+	assert.Greater(t, scoreSentiment("Great demo, really clear and helpful"), sentimentThreshold)
+	assert.Less(t, scoreSentiment("The release was late and the notes were confusing"), -sentimentThreshold)
+	assert.Less(t, scoreSentiment("This was not good"), -sentimentThreshold)
+	assert.Equal(t, 0.0, scoreSentiment("Met on Tuesday to discuss the roadmap"))
+	assert.Greater(t, scoreSentiment("very good"), scoreSentiment("good"))
+}
+
+func TestFeedbackRatingAndSentiment(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	alice := TeamMember{Name: "Alice", Email: "alice@example.com"}
+	bob := TeamMember{Name: "Bob", Email: "bob@example.com"}
+	DB.Create(&alice)
+	DB.Create(&bob)
+	team := Team{Name: "Platform", Members: []TeamMember{alice, bob}}
+	DB.Create(&team)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/api/v1/feedback", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	aliceTarget := `"target_type": "member", "target_id": ` + strconv.Itoa(int(alice.ID))
+
+	var created Feedback
+
+	t.Run("Feedback is scored on create", func(t *testing.T) {
+		w := post(`{` + aliceTarget + `, "content": "Excellent work on the migration", "rating": 5}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		json.Unmarshal(w.Body.Bytes(), &created)
+		assert.Equal(t, 5, *created.Rating)
+		assert.Greater(t, created.SentimentScore, sentimentThreshold)
+	})
+
+	t.Run("Reject rating out of range", func(t *testing.T) {
+		w := post(`{` + aliceTarget + `, "content": "Fine", "rating": 9}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Feedback is rescored on update", func(t *testing.T) {
+		body := `{"content": "The handover was sloppy and late", "kind": "constructive", "rating": 2}`
+		req, _ := http.NewRequest("PUT", "/api/v1/feedback/"+strconv.Itoa(int(created.ID)), bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response Feedback
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, created.ID, response.ID)
+		assert.Less(t, response.SentimentScore, -sentimentThreshold)
+	})
+
+	t.Run("Member sentiment summary", func(t *testing.T) {
+		post(`{` + aliceTarget + `, "content": "Thanks for the great support", "rating": 4}`)
+		post(`{` + aliceTarget + `, "content": "Awesome pairing session"}`)
+
+		req, _ := http.NewRequest("GET", "/api/v1/members/"+strconv.Itoa(int(alice.ID))+"/sentiment", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Total         int            `json:"total"`
+			Sentiment     map[string]int `json:"sentiment"`
+			AverageRating float64        `json:"average_rating"`
+			PositiveRatio float64        `json:"positive_ratio"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, 3, response.Total)
+		assert.Equal(t, 2, response.Sentiment["positive"])
+		assert.Equal(t, 1, response.Sentiment["negative"])
+		assert.Equal(t, 3.0, response.AverageRating)
+		assert.Equal(t, 0.67, response.PositiveRatio)
+	})
+
+	t.Run("Team sentiment includes members", func(t *testing.T) {
+		post(`{"target_type": "team", "target_id": ` + strconv.Itoa(int(team.ID)) + `, "content": "Strong quarter"}`)
+
+		req, _ := http.NewRequest("GET", "/api/v1/teams/"+strconv.Itoa(int(team.ID))+"/sentiment", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Total   int `json:"total"`
+			Members []struct {
+				MemberID uint `json:"member_id"`
+				Total    int  `json:"total"`
+			} `json:"members"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, 4, response.Total)
+		assert.Len(t, response.Members, 2)
+	})
+
+	t.Run("Archived feedback is left out", func(t *testing.T) {
+		DB.Model(&Feedback{ID: created.ID}).Update("archived_at", time.Now())
+
+		var response struct {
+			Total int `json:"total"`
+		}
+		for url, total := range map[string]int{
+			"/api/v1/members/" + strconv.Itoa(int(alice.ID)) + "/sentiment": 2,
+			"/api/v1/teams/" + strconv.Itoa(int(team.ID)) + "/sentiment":    3,
+		} {
+			req, _ := http.NewRequest("GET", url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			json.Unmarshal(w.Body.Bytes(), &response)
+			assert.Equal(t, total, response.Total, url)
+		}
+	})
+}
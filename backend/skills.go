@@ -0,0 +1,324 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	minSkillLevel = 1
+	maxSkillLevel = 5
+)
+
+func effectiveSkillLevel(skill *MemberSkill) int {
+	if skill.CoachLevel != nil {
+		return *skill.CoachLevel
+	}
+	if skill.SelfLevel != nil {
+		return *skill.SelfLevel
+	}
+	return 0
+}
+
+func validSkillLevel(level *int) bool {
+	return level == nil || (*level >= minSkillLevel && *level <= maxSkillLevel)
+}
+
+func findOrCreateSkill(name string) (Skill, error) {
+	var skill Skill
+	err := DB.Where("LOWER(name) = ?", strings.ToLower(name)).First(&skill).Error
+	if err == nil {
+		return skill, nil
+	}
+	skill = Skill{Name: name}
+	return skill, DB.Create(&skill).Error
+}
+
+func CreateSkill(c *gin.Context) {
+	var skill Skill
+	if err := c.ShouldBindJSON(&skill); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skill.Name = strings.TrimSpace(skill.Name)
+	if skill.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Name is required"})
+		return
+	}
+
+	var count int64
+	DB.Model(&Skill{}).Where("LOWER(name) = ?", strings.ToLower(skill.Name)).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Skill already exists"})
+		return
+	}
+
+	skill.ID = 0
+	if err := DB.Create(&skill).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, skill)
+}
+
+func GetSkills(c *gin.Context) {
+	var skills []Skill
+	if err := DB.Order("name").Find(&skills).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, skills)
+}
+
+func GetMemberSkills(c *gin.Context) {
+	id := c.Param("id")
+	var member TeamMember
+	if err := DB.First(&member, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	var skills []MemberSkill
+	if err := DB.Preload("Skill").Where("member_id = ?", member.ID).Find(&skills).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for i := range skills {
+		skills[i].Level = effectiveSkillLevel(&skills[i])
+	}
+	sort.SliceStable(skills, func(i, j int) bool { return skills[i].Level > skills[j].Level })
+	c.JSON(http.StatusOK, skills)
+}
+
+func SetMemberSkill(c *gin.Context) {
+	id := c.Param("id")
+	var member TeamMember
+	if err := DB.First(&member, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	var request MemberSkillRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !validSkillLevel(request.SelfLevel) || !validSkillLevel(request.CoachLevel) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Skill levels must be between 1 and 5"})
+		return
+	}
+
+	if request.SelfLevel == nil && request.CoachLevel == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A self or coach level is required"})
+		return
+	}
+
+	var skill Skill
+	switch {
+	case request.SkillID != 0:
+		if err := DB.First(&skill, request.SkillID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Skill not found"})
+			return
+		}
+	case strings.TrimSpace(request.SkillName) != "":
+		var err error
+		if skill, err = findOrCreateSkill(strings.TrimSpace(request.SkillName)); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Skill is required"})
+		return
+	}
+
+	var memberSkill MemberSkill
+	DB.Where("member_id = ? AND skill_id = ?", member.ID, skill.ID).First(&memberSkill)
+	memberSkill.MemberID = member.ID
+	memberSkill.SkillID = skill.ID
+	if request.SelfLevel != nil {
+		memberSkill.SelfLevel = request.SelfLevel
+	}
+	if request.CoachLevel != nil {
+		memberSkill.CoachLevel = request.CoachLevel
+	}
+
+	if err := DB.Omit("Skill").Save(&memberSkill).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	memberSkill.Skill = skill
+	memberSkill.Level = effectiveSkillLevel(&memberSkill)
+	c.JSON(http.StatusOK, memberSkill)
+}
+
+func DeleteMemberSkill(c *gin.Context) {
+	if err := DB.Where("member_id = ? AND skill_id = ?", c.Param("id"), c.Param("skillId")).Delete(&MemberSkill{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Skill removed from member"})
+}
+
+func GetTeamSkillsMatrix(c *gin.Context) {
+	id := c.Param("id")
+	var team Team
+	if err := DB.Preload("Members").First(&team, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	memberIDs := []uint{}
+	for _, member := range team.Members {
+		memberIDs = append(memberIDs, member.ID)
+	}
+
+	var memberSkills []MemberSkill
+	if len(memberIDs) > 0 {
+		if err := DB.Preload("Skill").Where("member_id IN ?", memberIDs).Find(&memberSkills).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	skillNames := map[uint]string{}
+	levels := map[uint]map[string]int{}
+	for i := range memberSkills {
+		skill := &memberSkills[i]
+		skillNames[skill.SkillID] = skill.Skill.Name
+		if levels[skill.MemberID] == nil {
+			levels[skill.MemberID] = map[string]int{}
+		}
+		levels[skill.MemberID][skill.Skill.Name] = effectiveSkillLevel(skill)
+	}
+
+	skills := []string{}
+	for _, name := range skillNames {
+		skills = append(skills, name)
+	}
+	sort.Strings(skills)
+
+	rows := []gin.H{}
+	for _, member := range team.Members {
+		memberLevels := levels[member.ID]
+		if memberLevels == nil {
+			memberLevels = map[string]int{}
+		}
+		rows = append(rows, gin.H{"member_id": member.ID, "name": member.Name, "levels": memberLevels})
+	}
+
+	coverage := []gin.H{}
+	for _, name := range skills {
+		holders, best := 0, 0
+		for _, memberLevels := range levels {
+			if level, ok := memberLevels[name]; ok {
+				holders++
+				if level > best {
+					best = level
+				}
+			}
+		}
+		coverage = append(coverage, gin.H{"skill": name, "members": holders, "max_level": best})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team_id": team.ID, "skills": skills, "members": rows, "coverage": coverage})
+}
+
+func parseSkillCriteria(c *gin.Context) (map[string]int, string) {
+	minLevel := minSkillLevel
+	if value := c.Query("min_level"); value != "" {
+		level, err := strconv.Atoi(value)
+		if err != nil || level < minSkillLevel || level > maxSkillLevel {
+			return nil, "min_level must be between 1 and 5"
+		}
+		minLevel = level
+	}
+
+	criteria := map[string]int{}
+	for _, param := range c.QueryArray("skills") {
+		for _, entry := range strings.Split(param, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			name, level := entry, minLevel
+			if i := strings.LastIndex(entry, ":"); i > 0 {
+				parsed, err := strconv.Atoi(entry[i+1:])
+				if err != nil || parsed < minSkillLevel || parsed > maxSkillLevel {
+					return nil, "Invalid level for skill " + entry[:i]
+				}
+				name, level = entry[:i], parsed
+			}
+			criteria[strings.ToLower(name)] = level
+		}
+	}
+
+	if len(criteria) == 0 {
+		return nil, "At least one skill is required"
+	}
+	return criteria, ""
+}
+
+func SearchMembersBySkill(c *gin.Context) {
+	criteria, msg := parseSkillCriteria(c)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	names := []string{}
+	for name := range criteria {
+		names = append(names, name)
+	}
+
+	var memberSkills []MemberSkill
+	if err := DB.Preload("Skill").
+		Joins("JOIN skills ON skills.id = member_skills.skill_id").
+		Where("LOWER(skills.name) IN ?", names).
+		Find(&memberSkills).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	matches := map[uint]map[string]int{}
+	for i := range memberSkills {
+		skill := &memberSkills[i]
+		level := effectiveSkillLevel(skill)
+		if level < criteria[strings.ToLower(skill.Skill.Name)] {
+			continue
+		}
+		if matches[skill.MemberID] == nil {
+			matches[skill.MemberID] = map[string]int{}
+		}
+		matches[skill.MemberID][skill.Skill.Name] = level
+	}
+
+	memberIDs := []uint{}
+	for memberID, skills := range matches {
+		if len(skills) == len(criteria) {
+			memberIDs = append(memberIDs, memberID)
+		}
+	}
+
+	results := []gin.H{}
+	if len(memberIDs) > 0 {
+		var members []TeamMember
+		if err := DB.Preload("Teams").Where("id IN ?", memberIDs).Order("name").Find(&members).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		for _, member := range members {
+			results = append(results, gin.H{"member": member, "skills": matches[member.ID]})
+		}
+	}
+
+	c.JSON(http.StatusOK, results)
+}
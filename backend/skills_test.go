@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberSkills(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	member := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	DB.Create(&member)
+	url := "/api/v1/members/" + strconv.Itoa(int(member.ID)) + "/skills"
+
+	t.Run("Coach level overrides self level", func(t *testing.T) {
+		req, _ := http.NewRequest("PUT", url, bytes.NewBufferString(`{"skill": "Go", "self_level": 2}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ = http.NewRequest("PUT", url, bytes.NewBufferString(`{"skill": "go", "coach_level": 4}`))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response MemberSkill
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, 4, response.Level)
+		assert.Equal(t, 2, *response.SelfLevel)
+
+		var count int64
+		DB.Model(&Skill{}).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Reject level out of range", func(t *testing.T) {
+		req, _ := http.NewRequest("PUT", url, bytes.NewBufferString(`{"skill": "Go", "self_level": 6}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSkillsMatrixAndSearch(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	alice := TeamMember{Name: "Alice", Email: "alice@example.com"}
+	bob := TeamMember{Name: "Bob", Email: "bob@example.com"}
+	DB.Create(&alice)
+	DB.Create(&bob)
+	team := Team{Name: "Platform", Members: []TeamMember{alice, bob}}
+	DB.Create(&team)
+
+	golang := Skill{Name: "Go"}
+	mysql := Skill{Name: "MySQL"}
+	DB.Create(&golang)
+	DB.Create(&mysql)
+
+	level := func(value int) *int { return &value }
+	DB.Create(&MemberSkill{MemberID: alice.ID, SkillID: golang.ID, SelfLevel: level(5)})
+	DB.Create(&MemberSkill{MemberID: alice.ID, SkillID: mysql.ID, SelfLevel: level(3)})
+	DB.Create(&MemberSkill{MemberID: bob.ID, SkillID: golang.ID, SelfLevel: level(5), CoachLevel: level(2)})
+
+	t.Run("Team skills matrix", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/teams/"+strconv.Itoa(int(team.ID))+"/skills-matrix", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Skills  []string `json:"skills"`
+			Members []struct {
+				MemberID uint           `json:"member_id"`
+				Levels   map[string]int `json:"levels"`
+			} `json:"members"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, []string{"Go", "MySQL"}, response.Skills)
+		assert.Len(t, response.Members, 2)
+		for _, row := range response.Members {
+			if row.MemberID == bob.ID {
+				assert.Equal(t, 2, row.Levels["Go"])
+			}
+		}
+	})
+
+	t.Run("Search by skill combination", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/members/search?skills=go,mysql&min_level=3", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response []struct {
+			Member TeamMember `json:"member"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response, 1)
+		assert.Equal(t, alice.ID, response[0].Member.ID)
+	})
+
+	t.Run("Search uses coach level", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/members/search?skills=go:4", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response []struct {
+			Member TeamMember `json:"member"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response, 1)
+		assert.Equal(t, alice.ID, response[0].Member.ID)
+	})
+
+	t.Run("Search requires skills", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/members/search", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
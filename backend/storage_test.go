@@ -0,0 +1,35 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	storage := NewLocalStorage(t.TempDir())
+
+	t.Run("Save, open and delete", func(t *testing.T) {
+		assert.NoError(t, storage.Save("members/1/avatar.png", strings.NewReader("image")))
+
+		reader, size, err := storage.Open("members/1/avatar.png")
+		assert.NoError(t, err)
+		data, _ := io.ReadAll(reader)
+		reader.Close()
+		assert.Equal(t, "image", string(data))
+		assert.Equal(t, int64(5), size)
+
+		assert.NoError(t, storage.Delete("members/1/avatar.png"))
+		assert.NoError(t, storage.Delete("members/1/avatar.png"))
+		_, _, err = storage.Open("members/1/avatar.png")
+		assert.Error(t, err)
+	})
+
+	t.Run("Reject keys outside the root", func(t *testing.T) {
+		for _, key := range []string{"", "/etc/passwd", "../secret", "members/../../secret", "members//1", `members\1`} {
+			assert.ErrorIs(t, storage.Save(key, strings.NewReader("x")), errInvalidStorageKey, key)
+		}
+	})
+}
@@ -0,0 +1,408 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const surveyTrendThreshold = 0.05
+
+var trafficLightScores = map[string]int{"red": 1, "yellow": 2, "green": 3}
+
+func validateSurveyTemplate(template *SurveyTemplate) string {
+	if template.Name == "" {
+		return "Name is required"
+	}
+	if len(template.Questions) == 0 {
+		return "At least one question is required"
+	}
+	for i := range template.Questions {
+		question := &template.Questions[i]
+		question.ID = 0
+		if question.Prompt == "" {
+			return "Question prompt is required"
+		}
+		if question.Position == 0 {
+			question.Position = i + 1
+		}
+		switch question.AnswerType {
+		case "scale":
+			if question.ScaleMin == 0 && question.ScaleMax == 0 {
+				question.ScaleMin, question.ScaleMax = 1, 5
+			}
+			if question.ScaleMax <= question.ScaleMin {
+				return "Scale maximum must be greater than scale minimum"
+			}
+		case "traffic_light", "free_text":
+			question.ScaleMin, question.ScaleMax = 0, 0
+		default:
+			return "Answer type must be 'scale', 'traffic_light' or 'free_text'"
+		}
+	}
+	return ""
+}
+
+func CreateSurveyTemplate(c *gin.Context) {
+	var template SurveyTemplate
+	if err := c.ShouldBindJSON(&template); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if msg := validateSurveyTemplate(&template); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	if err := DB.Create(&template).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+func GetSurveyTemplates(c *gin.Context) {
+	var templates []SurveyTemplate
+	if err := DB.Preload("Questions", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).Find(&templates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, templates)
+}
+
+func GetSurveyTemplate(c *gin.Context) {
+	id := c.Param("id")
+	var template SurveyTemplate
+	if err := DB.Preload("Questions", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).First(&template, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Survey template not found"})
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+func DeleteSurveyTemplate(c *gin.Context) {
+	id := c.Param("id")
+	var count int64
+	DB.Model(&SurveyRun{}).Where("survey_template_id = ?", id).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Survey template has runs and cannot be deleted"})
+		return
+	}
+
+	if err := DB.Where("survey_template_id = ?", id).Delete(&SurveyQuestion{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := DB.Delete(&SurveyTemplate{}, id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Survey template deleted"})
+}
+
+func CreateSurveyRun(c *gin.Context) {
+	var run SurveyRun
+	if err := c.ShouldBindJSON(&run); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var template SurveyTemplate
+	if err := DB.First(&template, run.SurveyTemplateID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Survey template not found"})
+		return
+	}
+
+	var team Team
+	if err := DB.First(&team, run.TeamID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	run.Status = "open"
+	if err := DB.Create(&run).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, run)
+}
+
+func GetSurveyRuns(c *gin.Context) {
+	query := DB.Model(&SurveyRun{})
+
+	if teamID := c.Query("team_id"); teamID != "" {
+		if id, err := strconv.Atoi(teamID); err == nil {
+			query = query.Where("team_id = ?", id)
+		}
+	}
+
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var runs []SurveyRun
+	if err := query.Order("created_at desc, id desc").Find(&runs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, runs)
+}
+
+func GetSurveyRun(c *gin.Context) {
+	id := c.Param("id")
+	var run SurveyRun
+	if err := DB.First(&run, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Survey run not found"})
+		return
+	}
+
+	var responses int64
+	DB.Model(&SurveyResponse{}).Where("survey_run_id = ?", run.ID).Count(&responses)
+	var expected int64
+	DB.Table("member_teams").Where("team_id = ?", run.TeamID).Count(&expected)
+
+	c.JSON(http.StatusOK, gin.H{"survey_run": run, "responses": responses, "expected_responses": expected})
+}
+
+func CloseSurveyRun(c *gin.Context) {
+	id := c.Param("id")
+	var run SurveyRun
+	if err := DB.First(&run, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Survey run not found"})
+		return
+	}
+
+	run.Status = "closed"
+	if err := DB.Model(&run).Update("status", run.Status).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, run)
+}
+
+func validateSurveyAnswer(question SurveyQuestion, answer *SurveyAnswer) string {
+	switch question.AnswerType {
+	case "scale":
+		if answer.Value == nil || *answer.Value < question.ScaleMin || *answer.Value > question.ScaleMax {
+			return "Answer to '" + question.Prompt + "' must be between " + strconv.Itoa(question.ScaleMin) + " and " + strconv.Itoa(question.ScaleMax)
+		}
+		answer.Light, answer.Text = "", ""
+	case "traffic_light":
+		if _, ok := trafficLightScores[answer.Light]; !ok {
+			return "Answer to '" + question.Prompt + "' must be 'green', 'yellow' or 'red'"
+		}
+		answer.Value, answer.Text = nil, ""
+	case "free_text":
+		answer.Value, answer.Light = nil, ""
+	}
+	return ""
+}
+
+func SubmitSurveyResponse(c *gin.Context) {
+	id := c.Param("id")
+	var run SurveyRun
+	if err := DB.First(&run, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Survey run not found"})
+		return
+	}
+
+	var response SurveyResponse
+	if err := c.ShouldBindJSON(&response); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if run.Status != "open" || (run.ClosesAt != nil && time.Now().After(*run.ClosesAt)) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Survey run is closed"})
+		return
+	}
+
+	if response.MemberID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "member_id is required"})
+		return
+	}
+
+	var membership int64
+	DB.Table("member_teams").Where("team_id = ? AND team_member_id = ?", run.TeamID, response.MemberID).Count(&membership)
+	if membership == 0 {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only members of the team can answer this survey"})
+		return
+	}
+
+	var existing int64
+	DB.Model(&SurveyResponse{}).Where("survey_run_id = ? AND member_id = ?", run.ID, response.MemberID).Count(&existing)
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Member has already answered this survey"})
+		return
+	}
+
+	var questions []SurveyQuestion
+	if err := DB.Where("survey_template_id = ?", run.SurveyTemplateID).Find(&questions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	byID := map[uint]SurveyQuestion{}
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+
+	answered := map[uint]bool{}
+	for i := range response.Answers {
+		answer := &response.Answers[i]
+		question, ok := byID[answer.SurveyQuestionID]
+		if !ok || answered[answer.SurveyQuestionID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Answers must reference each question of the survey at most once"})
+			return
+		}
+		if msg := validateSurveyAnswer(question, answer); msg != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+			return
+		}
+		answer.ID = 0
+		answered[answer.SurveyQuestionID] = true
+	}
+
+	response.ID = 0
+	response.SurveyRunID = run.ID
+	if err := DB.Create(&response).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+func surveyRunResults(run SurveyRun) ([]SurveyQuestionResult, int, error) {
+	var questions []SurveyQuestion
+	if err := DB.Where("survey_template_id = ?", run.SurveyTemplateID).Order("position").Find(&questions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var responses []SurveyResponse
+	if err := DB.Preload("Answers").Where("survey_run_id = ?", run.ID).Find(&responses).Error; err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]SurveyQuestionResult, 0, len(questions))
+	for _, question := range questions {
+		result := SurveyQuestionResult{QuestionID: question.ID, Prompt: question.Prompt, AnswerType: question.AnswerType}
+		if question.AnswerType == "traffic_light" {
+			result.LightCounts = map[string]int{"green": 0, "yellow": 0, "red": 0}
+		}
+		total := 0
+		for _, response := range responses {
+			for _, answer := range response.Answers {
+				if answer.SurveyQuestionID != question.ID {
+					continue
+				}
+				result.Responses++
+				switch question.AnswerType {
+				case "scale":
+					total += *answer.Value
+				case "traffic_light":
+					result.LightCounts[answer.Light]++
+					total += trafficLightScores[answer.Light]
+				case "free_text":
+					if answer.Text != "" {
+						result.Texts = append(result.Texts, answer.Text)
+					}
+				}
+			}
+		}
+		if question.AnswerType != "free_text" && result.Responses > 0 {
+			score := math.Round(float64(total)/float64(result.Responses)*100) / 100
+			result.Score = &score
+		}
+		results = append(results, result)
+	}
+	return results, len(responses), nil
+}
+
+func GetSurveyRunResults(c *gin.Context) {
+	id := c.Param("id")
+	var run SurveyRun
+	if err := DB.First(&run, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Survey run not found"})
+		return
+	}
+
+	results, responses, err := surveyRunResults(run)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"survey_run": run, "responses": responses, "questions": results})
+}
+
+func surveyTrend(previous, current *float64) string {
+	if previous == nil || current == nil {
+		return ""
+	}
+	switch diff := *current - *previous; {
+	case diff > surveyTrendThreshold:
+		return "up"
+	case diff < -surveyTrendThreshold:
+		return "down"
+	default:
+		return "flat"
+	}
+}
+
+func GetTeamHealth(c *gin.Context) {
+	id := c.Param("id")
+	var team Team
+	if err := DB.First(&team, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	query := DB.Where("team_id = ?", team.ID)
+	if templateID := c.Query("template_id"); templateID != "" {
+		if tid, err := strconv.Atoi(templateID); err == nil {
+			query = query.Where("survey_template_id = ?", tid)
+		}
+	}
+
+	var runs []SurveyRun
+	if err := query.Order("created_at, id").Find(&runs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	previous := map[uint]*float64{}
+	history := []gin.H{}
+	for _, run := range runs {
+		results, responses, err := surveyRunResults(run)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		for i := range results {
+			if last, ok := previous[results[i].QuestionID]; ok {
+				results[i].Trend = surveyTrend(last, results[i].Score)
+			}
+			if results[i].Score != nil {
+				previous[results[i].QuestionID] = results[i].Score
+			}
+		}
+		history = append(history, gin.H{
+			"survey_run_id":      run.ID,
+			"survey_template_id": run.SurveyTemplateID,
+			"date":               run.CreatedAt,
+			"status":             run.Status,
+			"responses":          responses,
+			"questions":          results,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"team_id": team.ID, "runs": history})
+}
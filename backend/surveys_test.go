@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSurveyTemplates(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	t.Run("Create squad health check template", func(t *testing.T) {
+		body := `{"name": "Squad health check", "questions": [
+			{"prompt": "Delivering value", "answer_type": "traffic_light"},
+			{"prompt": "Fun", "answer_type": "scale"},
+			{"prompt": "Anything else?", "answer_type": "free_text"}]}`
+		req, _ := http.NewRequest("POST", "/api/v1/survey-templates", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var response SurveyTemplate
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response.Questions, 3)
+		assert.Equal(t, 1, response.Questions[1].ScaleMin)
+		assert.Equal(t, 5, response.Questions[1].ScaleMax)
+	})
+
+	t.Run("Reject unknown answer type", func(t *testing.T) {
+		body := `{"name": "Broken", "questions": [{"prompt": "Mood", "answer_type": "emoji"}]}`
+		req, _ := http.NewRequest("POST", "/api/v1/survey-templates", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSurveyRunsAndTeamHealth(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	member1 := TeamMember{Name: "John Doe", Email: "john@example.com"}
+	member2 := TeamMember{Name: "Jane Smith", Email: "jane@example.com"}
+	outsider := TeamMember{Name: "Bob Johnson", Email: "bob@example.com"}
+	team := Team{Name: "Development Team"}
+	DB.Create(&member1)
+	DB.Create(&member2)
+	DB.Create(&outsider)
+	DB.Create(&team)
+	DB.Model(&team).Association("Members").Append(&member1, &member2)
+
+	template := SurveyTemplate{Name: "Health", Questions: []SurveyQuestion{
+		{Prompt: "Delivering value", AnswerType: "traffic_light", Position: 1},
+		{Prompt: "Fun", AnswerType: "scale", ScaleMin: 1, ScaleMax: 5, Position: 2},
+	}}
+	DB.Create(&template)
+	lightID := strconv.Itoa(int(template.Questions[0].ID))
+	scaleID := strconv.Itoa(int(template.Questions[1].ID))
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	answer := func(runID uint, memberID uint, light string, fun int) *httptest.ResponseRecorder {
+		body := `{"member_id": ` + strconv.Itoa(int(memberID)) + `, "answers": [
+			{"question_id": ` + lightID + `, "light": "` + light + `"},
+			{"question_id": ` + scaleID + `, "value": ` + strconv.Itoa(fun) + `}]}`
+		return post("/api/v1/survey-runs/"+strconv.Itoa(int(runID))+"/responses", body)
+	}
+
+	first := SurveyRun{SurveyTemplateID: template.ID, TeamID: team.ID, Status: "open", CreatedAt: time.Now().AddDate(0, 0, -14)}
+	DB.Create(&first)
+	answer(first.ID, member1.ID, "red", 2)
+	answer(first.ID, member2.ID, "yellow", 4)
+	post("/api/v1/survey-runs/"+strconv.Itoa(int(first.ID))+"/close", `{}`)
+
+	var second SurveyRun
+
+	t.Run("Start a survey run for a team", func(t *testing.T) {
+		w := post("/api/v1/survey-runs", `{"survey_template_id": `+strconv.Itoa(int(template.ID))+`, "team_id": `+strconv.Itoa(int(team.ID))+`}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &second)
+		assert.Equal(t, "open", second.Status)
+	})
+
+	t.Run("Collect responses from team members only", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, answer(second.ID, member1.ID, "blue", 3).Code)
+		assert.Equal(t, http.StatusBadRequest, answer(second.ID, member1.ID, "green", 9).Code)
+		assert.Equal(t, http.StatusCreated, answer(second.ID, member1.ID, "green", 3).Code)
+		assert.Equal(t, http.StatusCreated, answer(second.ID, member2.ID, "green", 3).Code)
+		assert.Equal(t, http.StatusConflict, answer(second.ID, member1.ID, "green", 3).Code)
+		assert.Equal(t, http.StatusForbidden, answer(second.ID, outsider.ID, "green", 3).Code)
+		assert.Equal(t, http.StatusConflict, answer(first.ID, member1.ID, "green", 3).Code)
+	})
+
+	t.Run("Aggregate results with trend", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/v1/teams/"+strconv.Itoa(int(team.ID))+"/health", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Runs []struct {
+				Responses int                    `json:"responses"`
+				Questions []SurveyQuestionResult `json:"questions"`
+			} `json:"runs"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response.Runs, 2)
+
+		latest := response.Runs[1]
+		assert.Equal(t, 2, latest.Responses)
+		assert.Equal(t, 2, latest.Questions[0].LightCounts["green"])
+		assert.Equal(t, 3.0, *latest.Questions[0].Score)
+		assert.Equal(t, "up", latest.Questions[0].Trend)
+		assert.Equal(t, "flat", latest.Questions[1].Trend)
+		assert.Empty(t, response.Runs[0].Questions[0].Trend)
+	})
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type feedbackTargetType struct {
+	Model    interface{}
+	NotFound string
+	Includes func(db *gorm.DB, targetID, memberID uint) bool
+}
+
+var feedbackTargetTypes = map[string]feedbackTargetType{}
+
+func registerFeedbackTargetType(name string, targetType feedbackTargetType) {
+	feedbackTargetTypes[name] = targetType
+}
+
+func init() {
+	registerFeedbackTargetType("member", feedbackTargetType{
+		Model:    &TeamMember{},
+		NotFound: "Team member not found",
+		Includes: func(db *gorm.DB, targetID, memberID uint) bool { return targetID == memberID },
+	})
+	registerFeedbackTargetType("team", feedbackTargetType{
+		Model:    &Team{},
+		NotFound: "Team not found",
+		Includes: func(db *gorm.DB, targetID, memberID uint) bool { return isTeamMember(targetID, memberID) },
+	})
+	registerFeedbackTargetType("project", feedbackTargetType{
+		Model:    &Project{},
+		NotFound: "Project not found",
+		Includes: isProjectMember,
+	})
+}
+
+func feedbackTargetTypeNames() []string {
+	names := []string{}
+	for name := range feedbackTargetTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func invalidTargetTypeMessage() string {
+	names := feedbackTargetTypeNames()
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "'" + name + "'"
+	}
+	return "Target type must be " + strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
+
+func feedbackTargetExists(db *gorm.DB, targetType string, targetID uint) bool {
+	definition, ok := feedbackTargetTypes[targetType]
+	if !ok {
+		return false
+	}
+	var count int64
+	db.Model(definition.Model).Where("id = ?", targetID).Count(&count)
+	return count > 0
+}
+
+func targetNotFoundMessage(targetType string) string {
+	if definition, ok := feedbackTargetTypes[targetType]; ok {
+		return definition.NotFound
+	}
+	return "Target not found"
+}
+
+func isFeedbackTarget(feedback *Feedback, memberID uint) bool {
+	definition, ok := feedbackTargetTypes[feedback.TargetType]
+	return ok && definition.Includes(DB, feedback.TargetID, memberID)
+}
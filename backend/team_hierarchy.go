@@ -0,0 +1,254 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errInvalidTeamParent = errors.New("invalid team parent")
+
+type teamHierarchy struct {
+	teams    map[uint]Team
+	children map[uint][]uint
+}
+
+func loadTeamHierarchy(db *gorm.DB) (*teamHierarchy, error) {
+	var teams []Team
+	if err := db.Order("name").Find(&teams).Error; err != nil {
+		return nil, err
+	}
+
+	hierarchy := &teamHierarchy{teams: map[uint]Team{}, children: map[uint][]uint{}}
+	for _, team := range teams {
+		hierarchy.teams[team.ID] = team
+		if team.ParentID != nil {
+			hierarchy.children[*team.ParentID] = append(hierarchy.children[*team.ParentID], team.ID)
+		}
+	}
+	return hierarchy, nil
+}
+
+func (h *teamHierarchy) descendants(teamID uint) []uint {
+	ids := []uint{}
+	queue := []uint{teamID}
+	seen := map[uint]bool{teamID: true}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range h.children[current] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+				queue = append(queue, child)
+			}
+		}
+	}
+	return ids
+}
+
+func (h *teamHierarchy) ancestors(teamID uint) []Team {
+	ancestors := []Team{}
+	seen := map[uint]bool{teamID: true}
+	for parentID := h.teams[teamID].ParentID; parentID != nil && !seen[*parentID]; {
+		parent, ok := h.teams[*parentID]
+		if !ok {
+			break
+		}
+		seen[parent.ID] = true
+		ancestors = append([]Team{parent}, ancestors...)
+		parentID = parent.ParentID
+	}
+	return ancestors
+}
+
+func validateTeamParent(tx *gorm.DB, teamID uint, parentID *uint) (int, string) {
+	if parentID == nil {
+		return 0, ""
+	}
+	if *parentID == teamID {
+		return http.StatusBadRequest, "A team cannot be its own parent"
+	}
+
+	hierarchy, err := loadTeamHierarchy(tx.Clauses(clause.Locking{Strength: "UPDATE"}))
+	if err != nil {
+		return http.StatusInternalServerError, err.Error()
+	}
+	if _, ok := hierarchy.teams[*parentID]; !ok {
+		return http.StatusBadRequest, "Parent team not found"
+	}
+	if teamID == 0 {
+		return 0, ""
+	}
+	for _, descendant := range hierarchy.descendants(teamID) {
+		if descendant == *parentID {
+			return http.StatusConflict, "A team cannot be moved under one of its own sub-teams"
+		}
+	}
+	return 0, ""
+}
+
+func teamMemberSets(teamIDs []uint) (map[uint]map[uint]bool, error) {
+	var rows []struct {
+		TeamID       uint
+		TeamMemberID uint
+	}
+	if err := DB.Table("member_teams").Select("team_id, team_member_id").Where("team_id IN ?", teamIDs).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	sets := map[uint]map[uint]bool{}
+	for _, row := range rows {
+		if sets[row.TeamID] == nil {
+			sets[row.TeamID] = map[uint]bool{}
+		}
+		sets[row.TeamID][row.TeamMemberID] = true
+	}
+	return sets, nil
+}
+
+func (h *teamHierarchy) buildNode(teamID uint, members map[uint]map[uint]bool, seen map[uint]bool) (TeamNode, map[uint]bool) {
+	seen[teamID] = true
+	team := h.teams[teamID]
+	node := TeamNode{ID: team.ID, Name: team.Name, Logo: team.Logo, ParentID: team.ParentID, MemberCount: len(members[teamID]), Children: []TeamNode{}}
+
+	subtree := map[uint]bool{}
+	for memberID := range members[teamID] {
+		subtree[memberID] = true
+	}
+	for _, childID := range h.children[teamID] {
+		if seen[childID] {
+			continue
+		}
+		child, childMembers := h.buildNode(childID, members, seen)
+		node.Children = append(node.Children, child)
+		for memberID := range childMembers {
+			subtree[memberID] = true
+		}
+	}
+	sort.SliceStable(node.Children, func(i, j int) bool { return node.Children[i].Name < node.Children[j].Name })
+
+	node.RollupMemberCount = len(subtree)
+	return node, subtree
+}
+
+func GetTeamSubtree(c *gin.Context) {
+	var team Team
+	if err := DB.First(&team, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	hierarchy, err := loadTeamHierarchy(DB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	teamIDs := append([]uint{team.ID}, hierarchy.descendants(team.ID)...)
+	members, err := teamMemberSets(teamIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	node, _ := hierarchy.buildNode(team.ID, members, map[uint]bool{})
+	c.JSON(http.StatusOK, node)
+}
+
+func GetTeamAncestors(c *gin.Context) {
+	var team Team
+	if err := DB.First(&team, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	hierarchy, err := loadTeamHierarchy(DB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, hierarchy.ancestors(team.ID))
+}
+
+func GetTeamFeedbackRollup(c *gin.Context) {
+	var team Team
+	if err := DB.First(&team, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	hierarchy, err := loadTeamHierarchy(DB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	teamIDs := append([]uint{team.ID}, hierarchy.descendants(team.ID)...)
+	members, err := teamMemberSets(teamIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	memberIDs := []uint{}
+	seen := map[uint]bool{}
+	for _, set := range members {
+		for memberID := range set {
+			if !seen[memberID] {
+				seen[memberID] = true
+				memberIDs = append(memberIDs, memberID)
+			}
+		}
+	}
+
+	query := DB.Scopes(revealedFeedback, publishedFeedback, activeFeedback)
+	if len(memberIDs) > 0 {
+		query = query.Where("(target_type = ? AND target_id IN ?) OR (target_type = ? AND target_id IN ?)", "team", teamIDs, "member", memberIDs)
+	} else {
+		query = query.Where("target_type = ? AND target_id IN ?", "team", teamIDs)
+	}
+
+	var feedbacks []Feedback
+	if err := query.Order("created_at desc, id desc").Find(&feedbacks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	teamCounts := map[uint]int{}
+	memberCounts := map[uint]int{}
+	for _, feedback := range feedbacks {
+		if feedback.TargetType == "team" {
+			teamCounts[feedback.TargetID]++
+		} else {
+			memberCounts[feedback.TargetID]++
+		}
+	}
+
+	byTeam := []gin.H{}
+	for _, teamID := range teamIDs {
+		memberFeedback := 0
+		for memberID := range members[teamID] {
+			memberFeedback += memberCounts[memberID]
+		}
+		byTeam = append(byTeam, gin.H{
+			"team_id":         teamID,
+			"name":            hierarchy.teams[teamID].Name,
+			"team_feedback":   teamCounts[teamID],
+			"member_feedback": memberFeedback,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"team_id":   team.ID,
+		"team_ids":  teamIDs,
+		"total":     len(feedbacks),
+		"by_team":   byTeam,
+		"feedbacks": feedbacks,
+	})
+}
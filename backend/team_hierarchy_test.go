@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamHierarchy(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	alice := TeamMember{Name: "Alice", Email: "alice@example.com"}
+	bob := TeamMember{Name: "Bob", Email: "bob@example.com"}
+	carol := TeamMember{Name: "Carol", Email: "carol@example.com"}
+	DB.Create(&alice)
+	DB.Create(&bob)
+	DB.Create(&carol)
+
+	department := Team{Name: "Engineering", Members: []TeamMember{alice}}
+	DB.Create(&department)
+	tribe := Team{Name: "Payments", ParentID: &department.ID, Members: []TeamMember{bob}}
+	DB.Create(&tribe)
+	squad := Team{Name: "Checkout", ParentID: &tribe.ID, Members: []TeamMember{bob, carol}}
+	DB.Create(&squad)
+
+	teamURL := func(team Team) string { return "/api/v1/teams/" + strconv.Itoa(int(team.ID)) }
+
+	t.Run("Subtree with member roll-up", func(t *testing.T) {
+		w := performRequest(router, "GET", teamURL(department)+"/subtree", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var node TeamNode
+		json.Unmarshal(w.Body.Bytes(), &node)
+		assert.Equal(t, 1, node.MemberCount)
+		assert.Equal(t, 3, node.RollupMemberCount)
+		assert.Len(t, node.Children, 1)
+		assert.Equal(t, 2, node.Children[0].RollupMemberCount)
+		assert.Equal(t, "Checkout", node.Children[0].Children[0].Name)
+	})
+
+	t.Run("Ancestors from the root", func(t *testing.T) {
+		w := performRequest(router, "GET", teamURL(squad)+"/ancestors", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var ancestors []Team
+		json.Unmarshal(w.Body.Bytes(), &ancestors)
+		assert.Len(t, ancestors, 2)
+		assert.Equal(t, department.ID, ancestors[0].ID)
+		assert.Equal(t, tribe.ID, ancestors[1].ID)
+	})
+
+	t.Run("Prevent cycles when re-parenting", func(t *testing.T) {
+		w := performRequest(router, "PUT", teamURL(department), `{"name": "Engineering", "parent_id": `+strconv.Itoa(int(squad.ID))+`}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+	})
+
+	t.Run("Reject invalid parents on update", func(t *testing.T) {
+		w := performRequest(router, "PUT", teamURL(tribe), `{"name": "Payments", "parent_id": `+strconv.Itoa(int(tribe.ID))+`}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = performRequest(router, "PUT", teamURL(tribe), `{"name": "Payments", "parent_id": 999}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Re-parent a team", func(t *testing.T) {
+		w := performRequest(router, "PUT", teamURL(squad), `{"name": "Checkout", "parent_id": `+strconv.Itoa(int(department.ID))+`}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var updated Team
+		json.Unmarshal(w.Body.Bytes(), &updated)
+		assert.Equal(t, squad.ID, updated.ID)
+		assert.Equal(t, department.ID, *updated.ParentID)
+	})
+
+	t.Run("Reject unknown parent on create", func(t *testing.T) {
+		w := performRequest(router, "POST", "/api/v1/teams", `{"name": "Orphan", "parent_id": 999}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Feedback roll-up across subtree", func(t *testing.T) {
+		DB.Create(&Feedback{Content: "Great quarter", TargetType: "team", TargetID: department.ID})
+		DB.Create(&Feedback{Content: "Smooth release", TargetType: "team", TargetID: squad.ID})
+		DB.Create(&Feedback{Content: "Helpful reviews", TargetType: "member", TargetID: carol.ID})
+
+		w := performRequest(router, "GET", teamURL(department)+"/feedback/rollup", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Total  int `json:"total"`
+			ByTeam []struct {
+				TeamID         uint `json:"team_id"`
+				TeamFeedback   int  `json:"team_feedback"`
+				MemberFeedback int  `json:"member_feedback"`
+			} `json:"by_team"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, 3, response.Total)
+		assert.Len(t, response.ByTeam, 3)
+
+		w = performRequest(router, "GET", teamURL(tribe)+"/feedback/rollup", "")
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, 0, response.Total)
+	})
+
+	t.Run("Deleting a team moves its children up", func(t *testing.T) {
+		w := performRequest(router, "DELETE", teamURL(department), "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var stored Team
+		DB.First(&stored, tribe.ID)
+		assert.Nil(t, stored.ParentID)
+	})
+
+	t.Run("Subtree tolerates a stored cycle", func(t *testing.T) {
+		loopA := Team{Name: "Loop A"}
+		DB.Create(&loopA)
+		loopB := Team{Name: "Loop B", ParentID: &loopA.ID}
+		DB.Create(&loopB)
+		DB.Model(&loopA).Update("parent_id", loopB.ID)
+
+		w := performRequest(router, "GET", teamURL(loopA)+"/subtree", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var node TeamNode
+		json.Unmarshal(w.Body.Bytes(), &node)
+		assert.Len(t, node.Children, 1)
+		assert.Empty(t, node.Children[0].Children)
+	})
+}
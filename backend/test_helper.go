@@ -6,11 +6,16 @@ import (
 )
 
 func SetupTestDB() *gorm.DB {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
 	if err != nil {
 		panic("failed to connect to test database")
 	}
 
+	err = setupJoinTables(db)
+	if err != nil {
+		panic("failed to set up join tables")
+	}
+
 	err = db.AutoMigrate(&Feedback{})
 	if err != nil {
 		panic("failed to migrate feedback table")
@@ -26,12 +31,113 @@ func SetupTestDB() *gorm.DB {
 		panic("failed to migrate team table")
 	}
 
+	err = db.AutoMigrate(&Goal{}, &KeyResult{}, &GoalCheckIn{})
+	if err != nil {
+		panic("failed to migrate goal tables")
+	}
+
+	err = db.AutoMigrate(&ActionItem{})
+	if err != nil {
+		panic("failed to migrate action item table")
+	}
+
+	err = db.AutoMigrate(&ReviewCycle{}, &Review{})
+	if err != nil {
+		panic("failed to migrate review cycle tables")
+	}
+
+	err = db.AutoMigrate(&FeedbackRequest{}, &FeedbackRequestRecipient{}, &Notification{})
+	if err != nil {
+		panic("failed to migrate feedback request tables")
+	}
+
+	err = db.AutoMigrate(&SurveyTemplate{}, &SurveyQuestion{}, &SurveyRun{}, &SurveyResponse{}, &SurveyAnswer{})
+	if err != nil {
+		panic("failed to migrate survey tables")
+	}
+
+	err = db.AutoMigrate(&Retrospective{}, &RetroColumn{}, &RetroCard{}, &RetroVote{})
+	if err != nil {
+		panic("failed to migrate retrospective tables")
+	}
+
+	err = db.AutoMigrate(&Competency{}, &CompetencyLevel{}, &CompetencyRating{}, &CompetencyExpectation{})
+	if err != nil {
+		panic("failed to migrate competency tables")
+	}
+
+	err = db.AutoMigrate(&Skill{}, &MemberSkill{})
+	if err != nil {
+		panic("failed to migrate skill tables")
+	}
+
+	err = db.AutoMigrate(&FeedbackTemplate{}, &FeedbackReply{})
+	if err != nil {
+		panic("failed to migrate feedback template tables")
+	}
+
+	err = db.AutoMigrate(&ApprovalPolicy{}, &FeedbackApproval{}, &FeedbackApprovalEvent{})
+	if err != nil {
+		panic("failed to migrate approval tables")
+	}
+
+	err = db.AutoMigrate(&Project{})
+	if err != nil {
+		panic("failed to migrate project tables")
+	}
+
+	err = db.AutoMigrate(&MembershipPeriod{})
+	if err != nil {
+		panic("failed to migrate membership period table")
+	}
+
+	err = db.AutoMigrate(&CustomFieldDefinition{}, &CustomFieldValue{})
+	if err != nil {
+		panic("failed to migrate custom field tables")
+	}
+
 	return db
 }
 
 func CleanupTestDB(db *gorm.DB) {
+	db.Exec("DELETE FROM membership_periods")
+	db.Exec("DELETE FROM custom_field_values")
+	db.Exec("DELETE FROM custom_field_definitions")
 	db.Exec("DELETE FROM member_teams")
 	db.Exec("DELETE FROM team_members")
 	db.Exec("DELETE FROM teams")
 	db.Exec("DELETE FROM feedbacks")
+	db.Exec("DELETE FROM goal_check_ins")
+	db.Exec("DELETE FROM key_results")
+	db.Exec("DELETE FROM goals")
+	db.Exec("DELETE FROM action_items")
+	db.Exec("DELETE FROM review_cycle_teams")
+	db.Exec("DELETE FROM reviews")
+	db.Exec("DELETE FROM review_cycles")
+	db.Exec("DELETE FROM feedback_request_recipients")
+	db.Exec("DELETE FROM feedback_requests")
+	db.Exec("DELETE FROM notifications")
+	db.Exec("DELETE FROM survey_answers")
+	db.Exec("DELETE FROM survey_responses")
+	db.Exec("DELETE FROM survey_runs")
+	db.Exec("DELETE FROM survey_questions")
+	db.Exec("DELETE FROM survey_templates")
+	db.Exec("DELETE FROM retro_votes")
+	db.Exec("DELETE FROM retro_cards")
+	db.Exec("DELETE FROM retro_columns")
+	db.Exec("DELETE FROM retrospectives")
+	db.Exec("DELETE FROM competency_expectations")
+	db.Exec("DELETE FROM competency_ratings")
+	db.Exec("DELETE FROM competency_levels")
+	db.Exec("DELETE FROM competencies")
+	db.Exec("DELETE FROM member_skills")
+	db.Exec("DELETE FROM skills")
+	db.Exec("DELETE FROM feedback_templates")
+	db.Exec("DELETE FROM feedback_replies")
+	db.Exec("DELETE FROM feedback_approval_events")
+	db.Exec("DELETE FROM feedback_approvals")
+	db.Exec("DELETE FROM approval_policies")
+	db.Exec("DELETE FROM project_members")
+	db.Exec("DELETE FROM project_teams")
+	db.Exec("DELETE FROM projects")
 }
\ No newline at end of file
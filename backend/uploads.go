@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultMaxUploadBytes = 5 << 20
+	maxImageDimension     = 4096
+	thumbnailSize         = 128
+	uploadFormOverhead    = 64 << 10
+	filesURLPrefix        = "/api/v1/files/"
+	fileCacheControl      = "public, max-age=31536000, immutable"
+)
+
+var imageExtensions = map[string]string{
+	"image/png":  "png",
+	"image/jpeg": "jpg",
+	"image/gif":  "gif",
+}
+
+var imageContentTypes = map[string]string{
+	".png": "image/png",
+	".jpg": "image/jpeg",
+	".gif": "image/gif",
+}
+
+type storedImage struct {
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+func fileURL(key string) string {
+	return filesURLPrefix + key
+}
+
+func thumbnailKey(key string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "_thumb.png"
+}
+
+func storedImageKey(url string) (string, bool) {
+	if !strings.HasPrefix(url, filesURLPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(url, filesURLPrefix), true
+}
+
+func deleteStoredImage(url string) {
+	key, ok := storedImageKey(url)
+	if !ok {
+		return
+	}
+	for _, stored := range []string{key, thumbnailKey(key)} {
+		if err := FileStorage.Delete(stored); err != nil {
+			log.Printf("Failed to delete stored image %s: %v", stored, err)
+		}
+	}
+}
+
+func resizeImage(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		size = max(width, height)
+	}
+	dstWidth, dstHeight := size, size
+	if width > height {
+		dstHeight = max(1, height*size/width)
+	} else if height > width {
+		dstWidth = max(1, width*size/height)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		y0 := bounds.Min.Y + y*height/dstHeight
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*height/dstHeight)
+		for x := 0; x < dstWidth; x++ {
+			x0 := bounds.Min.X + x*width/dstWidth
+			x1 := max(x0+1, bounds.Min.X+(x+1)*width/dstWidth)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			if a == 0 {
+				continue
+			}
+			dst.Set(x, y, color.NRGBA{
+				R: uint8(r * 0xff / a),
+				G: uint8(g * 0xff / a),
+				B: uint8(b * 0xff / a),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
+
+func processImageUpload(c *gin.Context, prefix string) (*storedImage, int, string) {
+	limit := int64(getEnvInt("MAX_UPLOAD_BYTES", defaultMaxUploadBytes))
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit+uploadFormOverhead)
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, http.StatusRequestEntityTooLarge, fmt.Sprintf("Image must be at most %d bytes", limit)
+		}
+		return nil, http.StatusBadRequest, "An image file is required in the 'file' field"
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		return nil, http.StatusBadRequest, err.Error()
+	}
+	if int64(len(data)) > limit {
+		return nil, http.StatusRequestEntityTooLarge, fmt.Sprintf("Image must be at most %d bytes", limit)
+	}
+
+	ext, ok := imageExtensions[http.DetectContentType(data)]
+	if !ok {
+		return nil, http.StatusUnsupportedMediaType, "Image must be a PNG, JPEG or GIF"
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, http.StatusBadRequest, "Image could not be decoded"
+	}
+	if config.Width > maxImageDimension || config.Height > maxImageDimension {
+		return nil, http.StatusBadRequest, fmt.Sprintf("Image must be at most %dx%d pixels", maxImageDimension, maxImageDimension)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, http.StatusBadRequest, "Image could not be decoded"
+	}
+
+	var thumbnail bytes.Buffer
+	if err := png.Encode(&thumbnail, resizeImage(img, thumbnailSize)); err != nil {
+		return nil, http.StatusInternalServerError, err.Error()
+	}
+
+	sum := sha256.Sum256(data)
+	key := prefix + "/" + hex.EncodeToString(sum[:8]) + "." + ext
+	if err := FileStorage.Save(key, bytes.NewReader(data)); err != nil {
+		return nil, http.StatusInternalServerError, err.Error()
+	}
+	if err := FileStorage.Save(thumbnailKey(key), &thumbnail); err != nil {
+		return nil, http.StatusInternalServerError, err.Error()
+	}
+	return &storedImage{URL: fileURL(key), ThumbnailURL: fileURL(thumbnailKey(key))}, 0, ""
+}
+
+func UploadMemberPicture(c *gin.Context) {
+	var member TeamMember
+	if err := DB.First(&member, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team member not found"})
+		return
+	}
+
+	stored, code, msg := processImageUpload(c, fmt.Sprintf("members/%d", member.ID))
+	if msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	previous := member.Picture
+	if err := DB.Model(&member).Update("picture", stored.URL).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if previous != stored.URL {
+		deleteStoredImage(previous)
+	}
+	c.JSON(http.StatusOK, stored)
+}
+
+func UploadTeamLogo(c *gin.Context) {
+	var team Team
+	if err := DB.First(&team, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		return
+	}
+
+	stored, code, msg := processImageUpload(c, fmt.Sprintf("teams/%d", team.ID))
+	if msg != "" {
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	previous := team.Logo
+	if err := DB.Model(&team).Update("logo", stored.URL).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if previous != stored.URL {
+		deleteStoredImage(previous)
+	}
+	c.JSON(http.StatusOK, stored)
+}
+
+func ServeFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	reader, size, err := FileStorage.Open(key)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+	defer reader.Close()
+
+	etag := `"` + path.Base(key) + `"`
+	if c.GetHeader("If-None-Match") == etag {
+		c.Header("Cache-Control", fileCacheControl)
+		c.Header("ETag", etag)
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	contentType, ok := imageContentTypes[path.Ext(key)]
+	if !ok {
+		contentType = "application/octet-stream"
+	}
+	c.DataFromReader(http.StatusOK, size, contentType, reader, map[string]string{
+		"Cache-Control": fileCacheControl,
+		"ETag":          etag,
+	})
+}
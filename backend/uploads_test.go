@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPNG(width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func multipartUpload(url string, data []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", "upload.png")
+	part.Write(data)
+	writer.Close()
+
+	req, _ := http.NewRequest("POST", url, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestImageUploads(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+	FileStorage = NewLocalStorage(t.TempDir())
+
+	router := setupTestRouter()
+
+	member := TeamMember{Name: "Uma", Email: "uma@example.com", Picture: "https://via.placeholder.com/150"}
+	DB.Create(&member)
+	team := Team{Name: "Design"}
+	DB.Create(&team)
+	memberURL := "/api/v1/members/" + strconv.Itoa(int(member.ID)) + "/picture"
+
+	var stored storedImage
+	t.Run("Upload a member picture", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, multipartUpload(memberURL, testPNG(300, 150)))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		json.Unmarshal(w.Body.Bytes(), &stored)
+		assert.Regexp(t, `^/api/v1/files/members/\d+/[0-9a-f]{16}\.png$`, stored.URL)
+		assert.Regexp(t, `_thumb\.png$`, stored.ThumbnailURL)
+
+		var updated TeamMember
+		DB.First(&updated, member.ID)
+		assert.Equal(t, stored.URL, updated.Picture)
+	})
+
+	t.Run("Serve files with caching headers", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", stored.ThumbnailURL, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Cache-Control"), "immutable")
+
+		thumbnail, err := png.Decode(w.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, 128, thumbnail.Bounds().Dx())
+		assert.Equal(t, 64, thumbnail.Bounds().Dy())
+
+		req, _ = http.NewRequest("GET", stored.ThumbnailURL, nil)
+		req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+
+		req, _ = http.NewRequest("GET", "/api/v1/files/members/../../etc/passwd", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.NotEqual(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Replacing a picture removes the old files", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, multipartUpload(memberURL, testPNG(40, 40)))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req, _ := http.NewRequest("GET", stored.URL, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		req, _ = http.NewRequest("GET", stored.URL, nil)
+		req.Header.Set("If-None-Match", `"`+path.Base(stored.URL)+`"`)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Upload a team logo", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, multipartUpload("/api/v1/teams/"+strconv.Itoa(int(team.ID))+"/logo", testPNG(64, 64)))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var updated Team
+		DB.First(&updated, team.ID)
+		assert.Regexp(t, `^/api/v1/files/teams/\d+/`, updated.Logo)
+	})
+
+	t.Run("Reject invalid uploads", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, multipartUpload(memberURL, []byte("not an image at all")))
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+		t.Setenv("MAX_UPLOAD_BYTES", "100")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, multipartUpload(memberURL, testPNG(64, 64)))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, multipartUpload(memberURL, make([]byte, 1<<20)))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+		req, _ := http.NewRequest("POST", memberURL, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, multipartUpload("/api/v1/members/9999/picture", testPNG(10, 10)))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
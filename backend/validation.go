@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(jsonFieldName)
+	if err := v.RegisterValidation("notblank", isNotBlank); err != nil {
+		panic(err)
+	}
+	if err := v.RegisterValidation("imageurl", isImageURL); err != nil {
+		panic(err)
+	}
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func isNotBlank(fl validator.FieldLevel) bool {
+	return strings.TrimSpace(fl.Field().String()) != ""
+}
+
+func isImageURL(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if strings.IndexFunc(value, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		return false
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	if parsed.Scheme == "" {
+		return parsed.Host == "" && !strings.HasPrefix(value, "//")
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func fieldErrorMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "notblank":
+		return "must not be blank"
+	case "email":
+		return "must be a valid email address"
+	case "imageurl":
+		return "must be an http(s) URL or a relative path"
+	case "max":
+		return "must be at most " + fe.Param() + " characters"
+	case "min":
+		return "must be at least " + fe.Param() + " characters"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "timezone":
+		return "must be an IANA time zone such as 'Europe/Berlin'"
+	default:
+		return "is invalid"
+	}
+}
+
+func bindJSON(c *gin.Context, obj interface{}) bool {
+	err := c.ShouldBindJSON(obj)
+	if err == nil {
+		return true
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+
+	fields := map[string]string{}
+	for _, fe := range validationErrors {
+		fields[fe.Field()] = fieldErrorMessage(fe)
+	}
+	respondFieldErrors(c, fields)
+	return false
+}
+
+func respondFieldErrors(c *gin.Context, fields map[string]string) {
+	c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Validation failed", "fields": fields})
+}
+
+func respondUniqueConflict(c *gin.Context, field string) {
+	c.JSON(http.StatusConflict, gin.H{"error": "Validation failed", "fields": gin.H{field: "is already in use"}})
+}
+
+func emailInUse(email string, exceptID uint) bool {
+	var count int64
+	DB.Model(&TeamMember{}).Where("LOWER(email) = ? AND id <> ?", strings.ToLower(email), exceptID).Count(&count)
+	return count > 0
+}
+
+func isUniqueViolation(err error) bool {
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
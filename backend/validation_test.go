@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInputValidation(t *testing.T) {
+	DB = SetupTestDB()
+	defer CleanupTestDB(DB)
+
+	router := setupTestRouter()
+
+	send := func(method, url, body string) (int, map[string]string) {
+		w := performRequest(router, method, url, body)
+
+		var response struct {
+			Fields map[string]string `json:"fields"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response.Fields
+	}
+
+	t.Run("Field-level errors for members", func(t *testing.T) {
+		code, fields := send("POST", "/api/v1/members", `{"name": "   ", "email": "ada@example.com", "picture": "javascript:alert(1)", "job_title": "`+strings.Repeat("x", 256)+`"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Equal(t, map[string]string{
+			"name":      "must not be blank",
+			"picture":   "must be an http(s) URL or a relative path",
+			"job_title": "must be at most 255 characters",
+		}, fields)
+
+		code, fields = send("POST", "/api/v1/members", `{"name": "Ada", "email": "ada@example.com", "status": "retired"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Equal(t, "must be one of: active, on-leave, alumni", fields["status"])
+	})
+
+	t.Run("Accept picture URLs and relative paths", func(t *testing.T) {
+		code, _ := send("POST", "/api/v1/members", `{"name": "Ada", "email": "ada@example.com", "picture": "https://cdn.example.com/ada.png"}`)
+		assert.Equal(t, http.StatusCreated, code)
+
+		code, _ = send("POST", "/api/v1/members", `{"name": "Bo", "email": "bo@example.com", "picture": "/api/v1/files/members/1/abc.png"}`)
+		assert.Equal(t, http.StatusCreated, code)
+
+		code, fields := send("POST", "/api/v1/teams", `{"name": "Ops", "logo": "ftp://example.com/logo.png"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Contains(t, fields, "logo")
+	})
+
+	t.Run("Duplicate emails conflict", func(t *testing.T) {
+		code, fields := send("POST", "/api/v1/members", `{"name": "Ada Again", "email": "ADA@example.com"}`)
+		assert.Equal(t, http.StatusConflict, code)
+		assert.Equal(t, "is already in use", fields["email"])
+
+		var bo TeamMember
+		DB.Where("email = ?", "bo@example.com").First(&bo)
+		code, _ = send("PUT", "/api/v1/members/"+strconv.Itoa(int(bo.ID)), `{"email": "ada@example.com"}`)
+		assert.Equal(t, http.StatusConflict, code)
+
+		code, _ = send("PUT", "/api/v1/members/"+strconv.Itoa(int(bo.ID)), `{"name": "Bo Renamed"}`)
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("Translated unique violations", func(t *testing.T) {
+		err := DB.Create(&TeamMember{Name: "Copy", Email: "ada@example.com"}).Error
+		assert.True(t, isUniqueViolation(err))
+	})
+
+	t.Run("Team updates are validated", func(t *testing.T) {
+		team := Team{Name: "Platform"}
+		DB.Create(&team)
+
+		code, fields := send("PUT", "/api/v1/teams/"+strconv.Itoa(int(team.ID)), `{"name": ""}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Equal(t, "is required", fields["name"])
+	})
+}
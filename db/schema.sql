@@ -10,8 +10,15 @@ CREATE TABLE IF NOT EXISTS team_members (
     name VARCHAR(255) NOT NULL,
     email VARCHAR(255) UNIQUE NOT NULL,
     picture VARCHAR(500),
+    job_title VARCHAR(255),
+    location VARCHAR(255),
+    timezone VARCHAR(64),
+    start_date TIMESTAMP NULL,
+    status ENUM('active', 'on-leave', 'alumni') NOT NULL DEFAULT 'active',
+    manager_id INT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (manager_id) REFERENCES team_members(id) ON DELETE SET NULL
 );
 
 -- Create teams table
@@ -19,16 +26,34 @@ CREATE TABLE IF NOT EXISTS teams (
     id INT PRIMARY KEY AUTO_INCREMENT,
     name VARCHAR(255) NOT NULL,
     logo VARCHAR(500),
+    parent_id INT NULL,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (parent_id) REFERENCES teams(id) ON DELETE SET NULL
 );
 
 -- Create feedback table
 CREATE TABLE IF NOT EXISTS feedbacks (
     id INT PRIMARY KEY AUTO_INCREMENT,
     content TEXT NOT NULL,
-    target_type ENUM('team', 'member') NOT NULL,
+    kind ENUM('kudos', 'constructive', 'observation') NOT NULL DEFAULT 'observation',
+    situation TEXT,
+    behavior TEXT,
+    impact TEXT,
+    template_id INT NULL,
+    rating TINYINT NULL,
+    sentiment_score DECIMAL(4,3) NOT NULL DEFAULT 0,
+    status ENUM('draft', 'scheduled', 'pending_approval', 'rejected', 'published') NOT NULL DEFAULT 'published',
+    release_at DATETIME NULL,
+    acknowledged_at DATETIME NULL,
+    acknowledged_by INT NULL,
+    archived_at DATETIME NULL,
+    target_type VARCHAR(50) NOT NULL,
     target_id INT NOT NULL,
+    author_id INT NULL,
+    feedback_request_id INT NULL,
+    anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    contributor_hash VARCHAR(64),
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
 );
@@ -37,16 +62,492 @@ CREATE TABLE IF NOT EXISTS feedbacks (
 CREATE TABLE IF NOT EXISTS member_teams (
     team_member_id INT,
     team_id INT,
+    role ENUM('lead', 'member', 'coach', 'observer') NOT NULL DEFAULT 'member',
+    allocation INT NOT NULL DEFAULT 100,
+    joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     PRIMARY KEY (team_member_id, team_id),
     FOREIGN KEY (team_member_id) REFERENCES team_members(id) ON DELETE CASCADE,
     FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE
 );
 
+-- Create goals table
+CREATE TABLE IF NOT EXISTS goals (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    owner_type ENUM('team', 'member') NOT NULL,
+    owner_id INT NOT NULL,
+    target_date DATETIME NULL,
+    progress INT NOT NULL DEFAULT 0,
+    status VARCHAR(50) NOT NULL DEFAULT 'not_started',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create key results table
+CREATE TABLE IF NOT EXISTS key_results (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    goal_id INT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    target_value DOUBLE NOT NULL DEFAULT 0,
+    current_value DOUBLE NOT NULL DEFAULT 0,
+    unit VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE
+);
+
+-- Create goal check-ins table
+CREATE TABLE IF NOT EXISTS goal_check_ins (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    goal_id INT NOT NULL,
+    progress INT NOT NULL DEFAULT 0,
+    status VARCHAR(50),
+    comment TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE
+);
+
+-- Create action items table
+CREATE TABLE IF NOT EXISTS action_items (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    feedback_id INT NULL,
+    meeting_title VARCHAR(255),
+    meeting_date DATETIME NULL,
+    retrospective_id INT NULL,
+    assignee_id INT NOT NULL,
+    due_date DATETIME NULL,
+    status ENUM('open', 'in-progress', 'done', 'dropped') NOT NULL DEFAULT 'open',
+    completed_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (feedback_id) REFERENCES feedbacks(id) ON DELETE SET NULL,
+    FOREIGN KEY (assignee_id) REFERENCES team_members(id) ON DELETE CASCADE
+);
+
+-- Create review cycles table
+CREATE TABLE IF NOT EXISTS review_cycles (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    period_start DATETIME NOT NULL,
+    period_end DATETIME NOT NULL,
+    self_review_deadline DATETIME NULL,
+    peer_review_deadline DATETIME NULL,
+    status ENUM('draft', 'open', 'calibration', 'closed') NOT NULL DEFAULT 'draft',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create many-to-many relationship table for review cycles and teams
+CREATE TABLE IF NOT EXISTS review_cycle_teams (
+    review_cycle_id INT,
+    team_id INT,
+    PRIMARY KEY (review_cycle_id, team_id),
+    FOREIGN KEY (review_cycle_id) REFERENCES review_cycles(id) ON DELETE CASCADE,
+    FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE
+);
+
+-- Create reviews table
+CREATE TABLE IF NOT EXISTS reviews (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    review_cycle_id INT NOT NULL,
+    type ENUM('self', 'peer') NOT NULL,
+    reviewer_id INT NULL,
+    reviewee_id INT NOT NULL,
+    strengths TEXT,
+    improvements TEXT,
+    comments TEXT,
+    status ENUM('draft', 'submitted') NOT NULL DEFAULT 'draft',
+    submitted_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_review (review_cycle_id, type, reviewer_id, reviewee_id),
+    FOREIGN KEY (review_cycle_id) REFERENCES review_cycles(id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewer_id) REFERENCES team_members(id) ON DELETE SET NULL,
+    FOREIGN KEY (reviewee_id) REFERENCES team_members(id) ON DELETE CASCADE
+);
+
+-- Create feedback requests table
+CREATE TABLE IF NOT EXISTS feedback_requests (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    requester_id INT NULL,
+    target_member_id INT NOT NULL,
+    message TEXT,
+    due_date DATETIME NULL,
+    status ENUM('open', 'completed') NOT NULL DEFAULT 'open',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (requester_id) REFERENCES team_members(id) ON DELETE SET NULL,
+    FOREIGN KEY (target_member_id) REFERENCES team_members(id) ON DELETE CASCADE
+);
+
+-- Create feedback request recipients table
+CREATE TABLE IF NOT EXISTS feedback_request_recipients (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    feedback_request_id INT NOT NULL,
+    recipient_id INT NOT NULL,
+    status ENUM('pending', 'completed', 'declined') NOT NULL DEFAULT 'pending',
+    decline_reason TEXT,
+    feedback_id INT NULL,
+    reminder_count INT NOT NULL DEFAULT 0,
+    last_reminded_at DATETIME NULL,
+    responded_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_request_recipient (feedback_request_id, recipient_id),
+    FOREIGN KEY (feedback_request_id) REFERENCES feedback_requests(id) ON DELETE CASCADE,
+    FOREIGN KEY (recipient_id) REFERENCES team_members(id) ON DELETE CASCADE,
+    FOREIGN KEY (feedback_id) REFERENCES feedbacks(id) ON DELETE SET NULL
+);
+
+-- Create notifications table
+CREATE TABLE IF NOT EXISTS notifications (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    member_id INT NOT NULL,
+    kind VARCHAR(50) NOT NULL,
+    message TEXT NOT NULL,
+    read_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (member_id) REFERENCES team_members(id) ON DELETE CASCADE
+);
+
+-- Create survey templates table
+CREATE TABLE IF NOT EXISTS survey_templates (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create survey questions table
+CREATE TABLE IF NOT EXISTS survey_questions (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    survey_template_id INT NOT NULL,
+    prompt VARCHAR(500) NOT NULL,
+    answer_type ENUM('scale', 'traffic_light', 'free_text') NOT NULL,
+    scale_min INT NOT NULL DEFAULT 0,
+    scale_max INT NOT NULL DEFAULT 0,
+    position INT NOT NULL DEFAULT 0,
+    FOREIGN KEY (survey_template_id) REFERENCES survey_templates(id) ON DELETE CASCADE
+);
+
+-- Create survey runs table
+CREATE TABLE IF NOT EXISTS survey_runs (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    survey_template_id INT NOT NULL,
+    team_id INT NOT NULL,
+    closes_at DATETIME NULL,
+    status ENUM('open', 'closed') NOT NULL DEFAULT 'open',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (survey_template_id) REFERENCES survey_templates(id),
+    FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE
+);
+
+-- Create survey responses table
+CREATE TABLE IF NOT EXISTS survey_responses (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    survey_run_id INT NOT NULL,
+    member_id INT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_survey_response_member (survey_run_id, member_id),
+    FOREIGN KEY (survey_run_id) REFERENCES survey_runs(id) ON DELETE CASCADE,
+    FOREIGN KEY (member_id) REFERENCES team_members(id) ON DELETE SET NULL
+);
+
+-- Create survey answers table
+CREATE TABLE IF NOT EXISTS survey_answers (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    survey_response_id INT NOT NULL,
+    survey_question_id INT NOT NULL,
+    value INT NULL,
+    light VARCHAR(10),
+    text TEXT,
+    FOREIGN KEY (survey_response_id) REFERENCES survey_responses(id) ON DELETE CASCADE,
+    FOREIGN KEY (survey_question_id) REFERENCES survey_questions(id) ON DELETE CASCADE
+);
+
+-- Create retrospectives table
+CREATE TABLE IF NOT EXISTS retrospectives (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    team_id INT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    status ENUM('open', 'closed') NOT NULL DEFAULT 'open',
+    votes_per_member INT NOT NULL DEFAULT 3,
+    feedback_id INT NULL,
+    closed_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE,
+    FOREIGN KEY (feedback_id) REFERENCES feedbacks(id) ON DELETE SET NULL
+);
+
+-- Create retrospective columns table
+CREATE TABLE IF NOT EXISTS retro_columns (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    retrospective_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    FOREIGN KEY (retrospective_id) REFERENCES retrospectives(id) ON DELETE CASCADE
+);
+
+-- Create retrospective cards table
+CREATE TABLE IF NOT EXISTS retro_cards (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    retrospective_id INT NOT NULL,
+    retro_column_id INT NOT NULL,
+    author_id INT NULL,
+    content TEXT NOT NULL,
+    group_id INT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (retrospective_id) REFERENCES retrospectives(id) ON DELETE CASCADE,
+    FOREIGN KEY (retro_column_id) REFERENCES retro_columns(id) ON DELETE CASCADE,
+    FOREIGN KEY (group_id) REFERENCES retro_cards(id) ON DELETE SET NULL
+);
+
+-- Create retrospective votes table
+CREATE TABLE IF NOT EXISTS retro_votes (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    retro_card_id INT NOT NULL,
+    member_id INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_retro_vote (retro_card_id, member_id),
+    FOREIGN KEY (retro_card_id) REFERENCES retro_cards(id) ON DELETE CASCADE,
+    FOREIGN KEY (member_id) REFERENCES team_members(id) ON DELETE CASCADE
+);
+
+-- Create competencies table
+CREATE TABLE IF NOT EXISTS competencies (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(255) UNIQUE NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create competency levels table
+CREATE TABLE IF NOT EXISTS competency_levels (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    competency_id INT NOT NULL,
+    level INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    UNIQUE KEY idx_competency_level (competency_id, level),
+    FOREIGN KEY (competency_id) REFERENCES competencies(id) ON DELETE CASCADE
+);
+
+-- Create competency ratings table
+CREATE TABLE IF NOT EXISTS competency_ratings (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    competency_id INT NOT NULL,
+    member_id INT NOT NULL,
+    rating INT NOT NULL,
+    feedback_id INT NULL,
+    review_id INT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (competency_id) REFERENCES competencies(id) ON DELETE CASCADE,
+    FOREIGN KEY (member_id) REFERENCES team_members(id) ON DELETE CASCADE,
+    FOREIGN KEY (feedback_id) REFERENCES feedbacks(id) ON DELETE CASCADE,
+    FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE CASCADE
+);
+
+-- Create competency expectations table
+CREATE TABLE IF NOT EXISTS competency_expectations (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    member_id INT NOT NULL,
+    competency_id INT NOT NULL,
+    expected_level INT NOT NULL,
+    UNIQUE KEY idx_member_competency (member_id, competency_id),
+    FOREIGN KEY (member_id) REFERENCES team_members(id) ON DELETE CASCADE,
+    FOREIGN KEY (competency_id) REFERENCES competencies(id) ON DELETE CASCADE
+);
+
+-- Create skills table
+CREATE TABLE IF NOT EXISTS skills (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create member skills table
+CREATE TABLE IF NOT EXISTS member_skills (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    member_id INT NOT NULL,
+    skill_id INT NOT NULL,
+    self_level INT,
+    coach_level INT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_member_skill (member_id, skill_id),
+    FOREIGN KEY (member_id) REFERENCES team_members(id) ON DELETE CASCADE,
+    FOREIGN KEY (skill_id) REFERENCES skills(id) ON DELETE CASCADE
+);
+
+-- Create feedback templates table
+CREATE TABLE IF NOT EXISTS feedback_templates (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    kind ENUM('kudos', 'constructive', 'observation') NOT NULL,
+    description TEXT,
+    required_fields JSON,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create feedback replies table
+CREATE TABLE IF NOT EXISTS feedback_replies (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    feedback_id INT NOT NULL,
+    parent_id INT NULL,
+    author_id INT NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (feedback_id) REFERENCES feedbacks(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES feedback_replies(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES team_members(id) ON DELETE CASCADE
+);
+
+-- Create approval policies table
+CREATE TABLE IF NOT EXISTS approval_policies (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    kind ENUM('kudos', 'constructive', 'observation') NOT NULL UNIQUE,
+    approver_id INT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (approver_id) REFERENCES team_members(id) ON DELETE SET NULL
+);
+
+-- Create feedback approvals table
+CREATE TABLE IF NOT EXISTS feedback_approvals (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    feedback_id INT NOT NULL UNIQUE,
+    status ENUM('pending', 'approved', 'rejected', 'changes_requested') NOT NULL DEFAULT 'pending',
+    requested_status ENUM('scheduled', 'published') NOT NULL,
+    approvers JSON,
+    decided_by INT NULL,
+    decided_at DATETIME NULL,
+    comment TEXT,
+    suggested_content TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (feedback_id) REFERENCES feedbacks(id) ON DELETE CASCADE,
+    FOREIGN KEY (decided_by) REFERENCES team_members(id) ON DELETE SET NULL
+);
+
+-- Create feedback approval events table
+CREATE TABLE IF NOT EXISTS feedback_approval_events (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    feedback_approval_id INT NOT NULL,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    actor_id INT NULL,
+    comment TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (feedback_approval_id) REFERENCES feedback_approvals(id) ON DELETE CASCADE
+);
+
+-- Create projects table
+CREATE TABLE IF NOT EXISTS projects (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    start_date DATETIME NULL,
+    end_date DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create project members table
+CREATE TABLE IF NOT EXISTS project_members (
+    project_id INT,
+    team_member_id INT,
+    PRIMARY KEY (project_id, team_member_id),
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (team_member_id) REFERENCES team_members(id) ON DELETE CASCADE
+);
+
+-- Create project teams table
+CREATE TABLE IF NOT EXISTS project_teams (
+    project_id INT,
+    team_id INT,
+    PRIMARY KEY (project_id, team_id),
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE
+);
+
+-- Create membership periods table
+CREATE TABLE IF NOT EXISTS membership_periods (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    team_member_id INT NOT NULL,
+    team_id INT NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    allocation INT NOT NULL,
+    joined_at TIMESTAMP NOT NULL,
+    left_at TIMESTAMP NULL,
+    reason VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create custom field tables
+CREATE TABLE IF NOT EXISTS custom_field_definitions (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    entity_type ENUM('member', 'team') NOT NULL,
+    field_key VARCHAR(100) NOT NULL,
+    label VARCHAR(255),
+    type ENUM('string', 'number', 'date', 'enum') NOT NULL,
+    options JSON,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_custom_field_key (entity_type, field_key)
+);
+
+CREATE TABLE IF NOT EXISTS custom_field_values (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    definition_id INT NOT NULL,
+    entity_id INT NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    UNIQUE KEY idx_custom_field_value (definition_id, entity_id),
+    FOREIGN KEY (definition_id) REFERENCES custom_field_definitions(id) ON DELETE CASCADE
+);
+
 -- Create indexes for better performance
 CREATE INDEX idx_team_members_email ON team_members(email);
 CREATE INDEX idx_feedback_target ON feedbacks(target_type, target_id);
-CREATE INDEX idx_member_teams_team ON member_teams(team_id);
+CREATE INDEX idx_team_members_manager ON team_members(manager_id);
+CREATE INDEX idx_team_members_status ON team_members(status);
+CREATE INDEX idx_member_teams_team ON member_teams(team_id, role);
 CREATE INDEX idx_member_teams_member ON member_teams(team_member_id);
+CREATE INDEX idx_goal_owner ON goals(owner_type, owner_id);
+CREATE INDEX idx_action_items_assignee ON action_items(assignee_id, status);
+CREATE INDEX idx_action_items_due_date ON action_items(due_date);
+CREATE INDEX idx_reviews_reviewee ON reviews(review_cycle_id, reviewee_id);
+CREATE INDEX idx_feedbacks_author ON feedbacks(author_id);
+CREATE INDEX idx_feedbacks_request ON feedbacks(feedback_request_id);
+CREATE INDEX idx_feedbacks_anonymous ON feedbacks(anonymous, target_type, target_id, contributor_hash);
+CREATE INDEX idx_notifications_member ON notifications(member_id, read_at);
+CREATE INDEX idx_survey_runs_team ON survey_runs(team_id, created_at);
+CREATE INDEX idx_action_items_retrospective ON action_items(retrospective_id);
+CREATE INDEX idx_competency_ratings_member ON competency_ratings(member_id, competency_id);
+CREATE INDEX idx_member_skills_skill ON member_skills(skill_id);
+CREATE INDEX idx_feedbacks_kind ON feedbacks(kind);
+CREATE INDEX idx_feedbacks_template ON feedbacks(template_id);
+CREATE INDEX idx_feedbacks_created_at ON feedbacks(created_at);
+CREATE INDEX idx_feedback_replies_feedback ON feedback_replies(feedback_id, parent_id);
+CREATE INDEX idx_feedbacks_unacknowledged ON feedbacks(target_type, target_id, acknowledged_at);
+CREATE INDEX idx_feedbacks_status_release ON feedbacks(status, release_at);
+CREATE INDEX idx_feedback_approvals_status ON feedback_approvals(status);
+CREATE INDEX idx_feedbacks_archived_at ON feedbacks(archived_at);
+CREATE INDEX idx_project_members_member ON project_members(team_member_id);
+CREATE INDEX idx_project_teams_team ON project_teams(team_id);
+CREATE INDEX idx_teams_parent ON teams(parent_id);
+CREATE INDEX idx_membership_periods_member ON membership_periods(team_member_id, joined_at);
+CREATE INDEX idx_membership_periods_team ON membership_periods(team_id, joined_at, left_at);
+CREATE INDEX idx_custom_field_values_lookup ON custom_field_values(definition_id, value);
+CREATE INDEX idx_feedbacks_target_sentiment ON feedbacks(target_type, target_id, sentiment_score);
 
 -- Insert sample data for testing
 INSERT INTO team_members (name, email, picture) VALUES